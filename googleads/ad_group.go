@@ -1,6 +1,7 @@
 package v201809
 
 import (
+	"context"
 	"encoding/xml"
 )
 
@@ -87,9 +88,16 @@ type AdGroupLabelOperations map[string][]AdGroupLabel
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201409/AdGroupService#get
 //
-func (s *AdGroupService) Get(selector Selector) (adGroups []AdGroup, totalCount int64, err error) {
+func (s *AdGroupService) Get(selector Selector, opts ...CallOption) (adGroups []AdGroup, totalCount int64, err error) {
+	return s.GetWithContext(context.Background(), selector, opts...)
+}
+
+// GetWithContext is the same as Get with the addition of a context.
+func (s *AdGroupService) GetWithContext(ctx context.Context, selector Selector, opts ...CallOption) (adGroups []AdGroup, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 	selector.XMLName = xml.Name{baseUrl, "serviceSelector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		adGroupServiceUrl,
 		"get",
 		struct {
@@ -117,6 +125,89 @@ func (s *AdGroupService) Get(selector Selector) (adGroups []AdGroup, totalCount
 	return getResp.AdGroups, getResp.Size, err
 }
 
+// GetEach is the same as Get but decodes the ad groups one at a time while
+// the response is read and passes each of them to fn instead of returning
+// them.  Decoding stops at the first error returned by fn.
+//
+// Example
+//
+//   totalCount, err := adGroupService.GetEach(selector, func(ag gads.AdGroup) error {
+//     ...
+//     return nil
+//   })
+//
+func (s *AdGroupService) GetEach(selector Selector, fn func(AdGroup) error, opts ...CallOption) (totalCount int64, err error) {
+	return s.GetEachWithContext(context.Background(), selector, fn, opts...)
+}
+
+// GetEachWithContext is the same as GetEach with the addition of a context.
+func (s *AdGroupService) GetEachWithContext(ctx context.Context, selector Selector, fn func(AdGroup) error, opts ...CallOption) (totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
+	selector.XMLName = xml.Name{Space: baseUrl, Local: "serviceSelector"}
+	return s.Auth.stream(
+		ctx,
+		adGroupServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Sel     Selector
+		}{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "get",
+			},
+			Sel: selector,
+		},
+		adGroupEntries(fn),
+	)
+}
+
+// AdGroupIterator walks the ad groups of a selector or query, see
+// AdGroupService.Iterate.
+type AdGroupIterator struct {
+	*pageIterator
+}
+
+// AdGroup returns the current ad group.
+func (it *AdGroupIterator) AdGroup() AdGroup {
+	return it.page.([]AdGroup)[it.index]
+}
+
+// Iterate returns an iterator over all ad groups matching selector, which
+// fetches them page by page, see CampaignService.Iterate.
+func (s *AdGroupService) Iterate(selector Selector, opts ...CallOption) *AdGroupIterator {
+	return s.IterateWithContext(context.Background(), selector, opts...)
+}
+
+// IterateWithContext is the same as Iterate with the addition of a context,
+// the iteration stops with the error of the context once it is done.
+func (s *AdGroupService) IterateWithContext(ctx context.Context, selector Selector, opts ...CallOption) *AdGroupIterator {
+	it := &AdGroupIterator{pageIterator: newPageIterator(ctx, selector, "Id")}
+	it.fetch = func(ctx context.Context, selector Selector) (interface{}, int, int64, error) {
+		adGroups, totalCount, err := s.GetWithContext(ctx, selector, opts...)
+		return adGroups, len(adGroups), totalCount, err
+	}
+	it.id = func(i int) int64 { return it.page.([]AdGroup)[i].Id }
+	return it
+}
+
+// IterateQuery returns an iterator over the ad groups of an AWQL query without
+// LIMIT clause, see Iterate.  Queries are not split on ids, the iteration
+// stops with ErrPagingLimit past 100,000 entries.
+func (s *AdGroupService) IterateQuery(query string, opts ...CallOption) *AdGroupIterator {
+	return s.IterateQueryWithContext(context.Background(), query, opts...)
+}
+
+// IterateQueryWithContext is the same as IterateQuery with the addition of a context.
+func (s *AdGroupService) IterateQueryWithContext(ctx context.Context, query string, opts ...CallOption) *AdGroupIterator {
+	it := &AdGroupIterator{pageIterator: newPageIterator(ctx, Selector{}, "")}
+	it.fetch = queryPage(query, func(ctx context.Context, query string) (interface{}, int, int64, error) {
+		adGroups, totalCount, err := s.QueryWithContext(ctx, query, opts...)
+		return adGroups, len(adGroups), totalCount, err
+	})
+	return it
+}
+
 // Mutate allows you to add, modify and remove ad group's, returning the
 // modified ad group's.
 //
@@ -155,7 +246,13 @@ func (s *AdGroupService) Get(selector Selector) (adGroups []AdGroup, totalCount
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201409/AdGroupService#mutate
 //
-func (s *AdGroupService) Mutate(adGroupOperations AdGroupOperations) (adGroups []AdGroup, err error) {
+func (s *AdGroupService) Mutate(adGroupOperations AdGroupOperations, opts ...CallOption) (adGroups []AdGroup, err error) {
+	return s.MutateWithContext(context.Background(), adGroupOperations, opts...)
+}
+
+// MutateWithContext is the same as Mutate with the addition of a context.
+func (s *AdGroupService) MutateWithContext(ctx context.Context, adGroupOperations AdGroupOperations, opts ...CallOption) (adGroups []AdGroup, err error) {
+	ctx = withCallOptions(ctx, opts)
 	type adGroupOperation struct {
 		Action  string  `xml:"operator"`
 		AdGroup AdGroup `xml:"operand"`
@@ -181,19 +278,20 @@ func (s *AdGroupService) Mutate(adGroupOperations AdGroupOperations) (adGroups [
 		},
 		Ops: operations,
 	}
-	respBody, err := s.Auth.request(adGroupServiceUrl, "mutate", mutation)
+	respBody, err := s.Auth.request(ctx, adGroupServiceUrl, "mutate", mutation)
 	if err != nil {
 		return adGroups, err
 	}
 	mutateResp := struct {
-		AdGroups []AdGroup `xml:"rval>value"`
+		AdGroups             []AdGroup            `xml:"rval>value"`
+		PartialFailureErrors partialFailureErrors `xml:"rval>partialFailureErrors"`
 	}{}
 	err = xml.Unmarshal([]byte(respBody), &mutateResp)
 	if err != nil {
 		return adGroups, err
 	}
 
-	return mutateResp.AdGroups, err
+	return mutateResp.AdGroups, partialFailure(operations, mutateResp.PartialFailureErrors)
 }
 
 // MutateLabel allows you to add and removes labels from ad groups.
@@ -215,7 +313,13 @@ func (s *AdGroupService) Mutate(adGroupOperations AdGroupOperations) (adGroups [
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201409/AdGroupService#mutateLabel
 //
-func (s *AdGroupService) MutateLabel(adGroupLabelOperations AdGroupLabelOperations) (adGroupLabels []AdGroupLabel, err error) {
+func (s *AdGroupService) MutateLabel(adGroupLabelOperations AdGroupLabelOperations, opts ...CallOption) (adGroupLabels []AdGroupLabel, err error) {
+	return s.MutateLabelWithContext(context.Background(), adGroupLabelOperations, opts...)
+}
+
+// MutateLabelWithContext is the same as MutateLabel with the addition of a context.
+func (s *AdGroupService) MutateLabelWithContext(ctx context.Context, adGroupLabelOperations AdGroupLabelOperations, opts ...CallOption) (adGroupLabels []AdGroupLabel, err error) {
+	ctx = withCallOptions(ctx, opts)
 	type adGroupLabelOperation struct {
 		Action       string       `xml:"operator"`
 		AdGroupLabel AdGroupLabel `xml:"operand"`
@@ -240,28 +344,36 @@ func (s *AdGroupService) MutateLabel(adGroupLabelOperations AdGroupLabelOperatio
 			Local: "mutateLabel",
 		},
 		Ops: operations}
-	respBody, err := s.Auth.request(adGroupServiceUrl, "mutateLabel", mutation)
+	respBody, err := s.Auth.request(ctx, adGroupServiceUrl, "mutateLabel", mutation)
 	if err != nil {
 		return adGroupLabels, err
 	}
 	mutateResp := struct {
-		AdGroupLabels []AdGroupLabel `xml:"rval>value"`
+		AdGroupLabels        []AdGroupLabel       `xml:"rval>value"`
+		PartialFailureErrors partialFailureErrors `xml:"rval>partialFailureErrors"`
 	}{}
 	err = xml.Unmarshal([]byte(respBody), &mutateResp)
 	if err != nil {
 		return adGroupLabels, err
 	}
 
-	return mutateResp.AdGroupLabels, err
+	return mutateResp.AdGroupLabels, partialFailure(operations, mutateResp.PartialFailureErrors)
 }
 
 // Relevant documentation
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201409/AdGroupService#query
 //
-func (s *AdGroupService) Query(query string) (adGroups []AdGroup, totalCount int64, err error) {
+func (s *AdGroupService) Query(query string, opts ...CallOption) (adGroups []AdGroup, totalCount int64, err error) {
+	return s.QueryWithContext(context.Background(), query, opts...)
+}
+
+// QueryWithContext is the same as Query with the addition of a context.
+func (s *AdGroupService) QueryWithContext(ctx context.Context, query string, opts ...CallOption) (adGroups []AdGroup, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 
 	respBody, err := s.Auth.request(
+		ctx,
 		adGroupServiceUrl,
 		"query",
 		AWQLQuery{
@@ -289,3 +401,38 @@ func (s *AdGroupService) Query(query string) (adGroups []AdGroup, totalCount int
 	return getResp.AdGroups, getResp.Size, err
 
 }
+
+// QueryEach is the same as Query but passes the ad groups to fn one at a time
+// as they are decoded, see GetEach.
+func (s *AdGroupService) QueryEach(query string, fn func(AdGroup) error, opts ...CallOption) (totalCount int64, err error) {
+	return s.QueryEachWithContext(context.Background(), query, fn, opts...)
+}
+
+// QueryEachWithContext is the same as QueryEach with the addition of a context.
+func (s *AdGroupService) QueryEachWithContext(ctx context.Context, query string, fn func(AdGroup) error, opts ...CallOption) (totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
+	return s.Auth.stream(
+		ctx,
+		adGroupServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+		adGroupEntries(fn),
+	)
+}
+
+// adGroupEntries decodes streamed ad groups for fn.
+func adGroupEntries(fn func(AdGroup) error) entryFunc {
+	return func(dec *xml.Decoder, start *xml.StartElement) error {
+		var adGroup AdGroup
+		if err := dec.DecodeElement(&adGroup, start); err != nil {
+			return err
+		}
+		return fn(adGroup)
+	}
+}
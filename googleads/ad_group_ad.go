@@ -1,6 +1,7 @@
 package v201809
 
 import (
+	"context"
 	"encoding/xml"
 )
 
@@ -767,9 +768,16 @@ type AdGroupAdPolicySummary struct {
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201409/AdGroupAdService#get
 //
-func (s AdGroupAdService) Get(selector Selector) (adGroupAds AdGroupAds, totalCount int64, err error) {
+func (s AdGroupAdService) Get(selector Selector, opts ...CallOption) (adGroupAds AdGroupAds, totalCount int64, err error) {
+	return s.GetWithContext(context.Background(), selector, opts...)
+}
+
+// GetWithContext is the same as Get with the addition of a context.
+func (s AdGroupAdService) GetWithContext(ctx context.Context, selector Selector, opts ...CallOption) (adGroupAds AdGroupAds, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 	selector.XMLName = xml.Name{baseUrl, "serviceSelector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		adGroupAdServiceUrl,
 		"get",
 		struct {
@@ -797,6 +805,89 @@ func (s AdGroupAdService) Get(selector Selector) (adGroupAds AdGroupAds, totalCo
 	return getResp.AdGroupAds, getResp.Size, err
 }
 
+// GetEach is the same as Get but decodes the ads one at a time while
+// the response is read and passes each of them to fn instead of returning
+// them.  Decoding stops at the first error returned by fn.
+//
+// Example
+//
+//   totalCount, err := adGroupAdService.GetEach(selector, func(adGroupAd interface{}) error {
+//     ...
+//     return nil
+//   })
+//
+func (s *AdGroupAdService) GetEach(selector Selector, fn func(interface{}) error, opts ...CallOption) (totalCount int64, err error) {
+	return s.GetEachWithContext(context.Background(), selector, fn, opts...)
+}
+
+// GetEachWithContext is the same as GetEach with the addition of a context.
+func (s *AdGroupAdService) GetEachWithContext(ctx context.Context, selector Selector, fn func(interface{}) error, opts ...CallOption) (totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
+	selector.XMLName = xml.Name{Space: baseUrl, Local: "serviceSelector"}
+	return s.Auth.stream(
+		ctx,
+		adGroupAdServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Sel     Selector
+		}{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "get",
+			},
+			Sel: selector,
+		},
+		adGroupAdEntries(fn),
+	)
+}
+
+// AdGroupAdIterator walks the ad group ads of a selector or query, see
+// AdGroupAdService.Iterate.
+type AdGroupAdIterator struct {
+	*pageIterator
+}
+
+// AdGroupAd returns the current ad group ad.
+func (it *AdGroupAdIterator) AdGroupAd() interface{} {
+	return it.page.(AdGroupAds)[it.index]
+}
+
+// Iterate returns an iterator over all ad group ads matching selector, which
+// fetches them page by page, see CampaignService.Iterate.
+func (s AdGroupAdService) Iterate(selector Selector, opts ...CallOption) *AdGroupAdIterator {
+	return s.IterateWithContext(context.Background(), selector, opts...)
+}
+
+// IterateWithContext is the same as Iterate with the addition of a context,
+// the iteration stops with the error of the context once it is done.
+func (s AdGroupAdService) IterateWithContext(ctx context.Context, selector Selector, opts ...CallOption) *AdGroupAdIterator {
+	it := &AdGroupAdIterator{pageIterator: newPageIterator(ctx, selector, "Id", "AdGroupId")}
+	it.fetch = func(ctx context.Context, selector Selector) (interface{}, int, int64, error) {
+		adGroupAds, totalCount, err := s.GetWithContext(ctx, selector, opts...)
+		return adGroupAds, len(adGroupAds), totalCount, err
+	}
+	it.id = func(i int) int64 { return entityId(it.page.(AdGroupAds)[i], "Id") }
+	return it
+}
+
+// IterateQuery returns an iterator over the ad group ads of an AWQL query without
+// LIMIT clause, see Iterate.  Queries are not split on ids, the iteration
+// stops with ErrPagingLimit past 100,000 entries.
+func (s *AdGroupAdService) IterateQuery(query string, opts ...CallOption) *AdGroupAdIterator {
+	return s.IterateQueryWithContext(context.Background(), query, opts...)
+}
+
+// IterateQueryWithContext is the same as IterateQuery with the addition of a context.
+func (s *AdGroupAdService) IterateQueryWithContext(ctx context.Context, query string, opts ...CallOption) *AdGroupAdIterator {
+	it := &AdGroupAdIterator{pageIterator: newPageIterator(ctx, Selector{}, "")}
+	it.fetch = queryPage(query, func(ctx context.Context, query string) (interface{}, int, int64, error) {
+		adGroupAds, totalCount, err := s.QueryWithContext(ctx, query, opts...)
+		return adGroupAds, len(adGroupAds), totalCount, err
+	})
+	return it
+}
+
 // Mutate allows you to add, modify and remove ads, returning the
 // modified ads.
 //
@@ -828,23 +919,58 @@ func (s AdGroupAdService) Get(selector Selector) (adGroupAds AdGroupAds, totalCo
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201409/AdGroupAdService#mutate
 //
-func (s *AdGroupAdService) Mutate(adGroupAdOperations AdGroupAdOperations) (adGroupAds AdGroupAds, err error) {
-	type adGroupAdOperation struct {
-		Action    string     `xml:"operator"`
-		AdGroupAd AdGroupAds `xml:"operand"`
-	}
-	operations := []adGroupAdOperation{}
+func (s *AdGroupAdService) Mutate(adGroupAdOperations AdGroupAdOperations, opts ...CallOption) (adGroupAds AdGroupAds, err error) {
+	return s.MutateWithContext(context.Background(), adGroupAdOperations, opts...)
+}
+
+// MutateWithContext is the same as Mutate with the addition of a context.
+func (s *AdGroupAdService) MutateWithContext(ctx context.Context, adGroupAdOperations AdGroupAdOperations, opts ...CallOption) (adGroupAds AdGroupAds, err error) {
+	ctx = withCallOptions(ctx, opts)
+	operations := []AdGroupAdOperation{}
 	for action, adGroupAds := range adGroupAdOperations {
 		for _, adGroupAd := range adGroupAds {
-			ad := []interface{}{adGroupAd}
 			operations = append(operations,
-				adGroupAdOperation{
+				AdGroupAdOperation{
 					Action:    action,
-					AdGroupAd: ad,
+					AdGroupAd: adGroupAd,
 				},
 			)
 		}
 	}
+	return s.MutateOperationsWithContext(ctx, operations)
+}
+
+type AdGroupAdOperation struct {
+	Action            string             `xml:"operator"`
+	AdGroupAd         interface{}        `xml:"operand"`
+	ExemptionRequests []ExemptionRequest `xml:"exemptionRequests,omitempty"`
+}
+
+// MutateOperations is the same as Mutate but sends the operations in the
+// given order, which partial failure errors refer to.
+func (s *AdGroupAdService) MutateOperations(operations []AdGroupAdOperation, opts ...CallOption) (adGroupAds AdGroupAds, err error) {
+	return s.MutateOperationsWithContext(context.Background(), operations, opts...)
+}
+
+// MutateOperationsWithContext is the same as MutateOperations with the addition of a context.
+func (s *AdGroupAdService) MutateOperationsWithContext(ctx context.Context, operations []AdGroupAdOperation, opts ...CallOption) (adGroupAds AdGroupAds, err error) {
+	ctx = withCallOptions(ctx, opts)
+	// ads are wrapped in AdGroupAds for its custom marshalling
+	type adGroupAdOperation struct {
+		Action            string             `xml:"operator"`
+		AdGroupAd         AdGroupAds         `xml:"operand"`
+		ExemptionRequests []ExemptionRequest `xml:"exemptionRequests,omitempty"`
+	}
+	ops := []adGroupAdOperation{}
+	for _, op := range operations {
+		ops = append(ops,
+			adGroupAdOperation{
+				Action:            op.Action,
+				AdGroupAd:         AdGroupAds{op.AdGroupAd},
+				ExemptionRequests: op.ExemptionRequests,
+			},
+		)
+	}
 	mutation := struct {
 		XMLName xml.Name
 		Ops     []adGroupAdOperation `xml:"operations"`
@@ -853,21 +979,22 @@ func (s *AdGroupAdService) Mutate(adGroupAdOperations AdGroupAdOperations) (adGr
 			Space: baseUrl,
 			Local: "mutate",
 		},
-		Ops: operations,
+		Ops: ops,
 	}
 
-	respBody, err := s.Auth.request(adGroupAdServiceUrl, "mutate", mutation)
+	respBody, err := s.Auth.request(ctx, adGroupAdServiceUrl, "mutate", mutation)
 	if err != nil {
 		return adGroupAds, err
 	}
 	mutateResp := struct {
-		AdGroupAds AdGroupAds `xml:"rval>value"`
+		AdGroupAds           AdGroupAds           `xml:"rval>value"`
+		PartialFailureErrors partialFailureErrors `xml:"rval>partialFailureErrors"`
 	}{}
 	err = xml.Unmarshal([]byte(respBody), &mutateResp)
 	if err != nil {
 		return adGroupAds, err
 	}
-	return mutateResp.AdGroupAds, err
+	return mutateResp.AdGroupAds, partialFailure(operations, mutateResp.PartialFailureErrors)
 }
 
 // MutateLabel allows you to add and removes labels from ads.
@@ -889,7 +1016,13 @@ func (s *AdGroupAdService) Mutate(adGroupAdOperations AdGroupAdOperations) (adGr
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201409/AdGroupAdService#mutateLabel
 //
-func (s *AdGroupAdService) MutateLabel(adGroupAdLabelOperations AdGroupAdLabelOperations) (adGroupAdLabels []AdGroupAdLabel, err error) {
+func (s *AdGroupAdService) MutateLabel(adGroupAdLabelOperations AdGroupAdLabelOperations, opts ...CallOption) (adGroupAdLabels []AdGroupAdLabel, err error) {
+	return s.MutateLabelWithContext(context.Background(), adGroupAdLabelOperations, opts...)
+}
+
+// MutateLabelWithContext is the same as MutateLabel with the addition of a context.
+func (s *AdGroupAdService) MutateLabelWithContext(ctx context.Context, adGroupAdLabelOperations AdGroupAdLabelOperations, opts ...CallOption) (adGroupAdLabels []AdGroupAdLabel, err error) {
+	ctx = withCallOptions(ctx, opts)
 	type adGroupAdLabelOperation struct {
 		Action         string         `xml:"operator"`
 		AdGroupAdLabel AdGroupAdLabel `xml:"operand"`
@@ -914,19 +1047,20 @@ func (s *AdGroupAdService) MutateLabel(adGroupAdLabelOperations AdGroupAdLabelOp
 			Local: "mutateLabel",
 		},
 		Ops: operations}
-	respBody, err := s.Auth.request(adGroupAdServiceUrl, "mutateLabel", mutation)
+	respBody, err := s.Auth.request(ctx, adGroupAdServiceUrl, "mutateLabel", mutation)
 	if err != nil {
 		return adGroupAdLabels, err
 	}
 	mutateResp := struct {
-		AdGroupAdLabels []AdGroupAdLabel `xml:"rval>value"`
+		AdGroupAdLabels      []AdGroupAdLabel     `xml:"rval>value"`
+		PartialFailureErrors partialFailureErrors `xml:"rval>partialFailureErrors"`
 	}{}
 	err = xml.Unmarshal([]byte(respBody), &mutateResp)
 	if err != nil {
 		return adGroupAdLabels, err
 	}
 
-	return mutateResp.AdGroupAdLabels, err
+	return mutateResp.AdGroupAdLabels, partialFailure(operations, mutateResp.PartialFailureErrors)
 }
 
 // Query is not yet implemented
@@ -935,9 +1069,16 @@ func (s *AdGroupAdService) MutateLabel(adGroupAdLabelOperations AdGroupAdLabelOp
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201409/AdGroupAdService#query
 //
-func (s *AdGroupAdService) Query(query string) (adGroupAds AdGroupAds, totalCount int64, err error) {
+func (s *AdGroupAdService) Query(query string, opts ...CallOption) (adGroupAds AdGroupAds, totalCount int64, err error) {
+	return s.QueryWithContext(context.Background(), query, opts...)
+}
+
+// QueryWithContext is the same as Query with the addition of a context.
+func (s *AdGroupAdService) QueryWithContext(ctx context.Context, query string, opts ...CallOption) (adGroupAds AdGroupAds, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 
 	respBody, err := s.Auth.request(
+		ctx,
 		adGroupAdServiceUrl,
 		"query",
 		AWQLQuery{
@@ -967,6 +1108,46 @@ func (s *AdGroupAdService) Query(query string) (adGroupAds AdGroupAds, totalCoun
 	//return adGroupAds, totalCount, ERROR_NOT_YET_IMPLEMENTED
 }
 
+// QueryEach is the same as Query but passes the ads to fn one at a time
+// as they are decoded, see GetEach.
+func (s *AdGroupAdService) QueryEach(query string, fn func(interface{}) error, opts ...CallOption) (totalCount int64, err error) {
+	return s.QueryEachWithContext(context.Background(), query, fn, opts...)
+}
+
+// QueryEachWithContext is the same as QueryEach with the addition of a context.
+func (s *AdGroupAdService) QueryEachWithContext(ctx context.Context, query string, fn func(interface{}) error, opts ...CallOption) (totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
+	return s.Auth.stream(
+		ctx,
+		adGroupAdServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+		adGroupAdEntries(fn),
+	)
+}
+
+// adGroupAdEntries decodes streamed ads for fn.
+func adGroupAdEntries(fn func(interface{}) error) entryFunc {
+	return func(dec *xml.Decoder, start *xml.StartElement) error {
+		var adGroupAds AdGroupAds
+		if err := dec.DecodeElement(&adGroupAds, start); err != nil {
+			return err
+		}
+		for _, adGroupAd := range adGroupAds {
+			if err := fn(adGroupAd); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
 // Query is not yet implemented
 //
 // Relevant documentation
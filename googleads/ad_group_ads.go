@@ -247,6 +247,12 @@ func (aga *AdGroupAds) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) er
 			}
 		}
 	}
+	// operations that failed in a partial failure mutate have an empty
+	// value, keep their place
+	if ad == nil {
+		*aga = append(*aga, nil)
+		return nil
+	}
 	switch a := ad.(type) {
 	case TextAd:
 		a.Status = status
@@ -1,6 +1,7 @@
 package v201809
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 )
@@ -39,7 +40,10 @@ func (agcs *AdGroupCriterions) UnmarshalXML(dec *xml.Decoder, start xml.StartEle
 	adGroupCriterionType, err := findAttr(start.Attr, xml.Name{
 		Space: "http://www.w3.org/2001/XMLSchema-instance", Local: "type"})
 	if err != nil {
-		return err
+		// operations that failed in a partial failure mutate have an empty
+		// value, keep their place
+		*agcs = append(*agcs, nil)
+		return dec.Skip()
 	}
 	switch adGroupCriterionType {
 	case "BiddableAdGroupCriterion":
@@ -140,9 +144,16 @@ type AdGroupCriterionOperations map[string]AdGroupCriterions
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201409/AdGroupCriterionService#get
 //
-func (s AdGroupCriterionService) Get(selector Selector) (adGroupCriterions AdGroupCriterions, totalCount int64, err error) {
+func (s AdGroupCriterionService) Get(selector Selector, opts ...CallOption) (adGroupCriterions AdGroupCriterions, totalCount int64, err error) {
+	return s.GetWithContext(context.Background(), selector, opts...)
+}
+
+// GetWithContext is the same as Get with the addition of a context.
+func (s AdGroupCriterionService) GetWithContext(ctx context.Context, selector Selector, opts ...CallOption) (adGroupCriterions AdGroupCriterions, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 	selector.XMLName = xml.Name{baseUrl, "serviceSelector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		adGroupCriterionServiceUrl,
 		"get",
 		struct {
@@ -170,6 +181,89 @@ func (s AdGroupCriterionService) Get(selector Selector) (adGroupCriterions AdGro
 	return getResp.AdGroupCriterions, getResp.Size, err
 }
 
+// GetEach is the same as Get but decodes the criteria one at a time while
+// the response is read and passes each of them to fn instead of returning
+// them.  Decoding stops at the first error returned by fn.
+//
+// Example
+//
+//   totalCount, err := adGroupCriterionService.GetEach(selector, func(adGroupCriterion interface{}) error {
+//     ...
+//     return nil
+//   })
+//
+func (s *AdGroupCriterionService) GetEach(selector Selector, fn func(interface{}) error, opts ...CallOption) (totalCount int64, err error) {
+	return s.GetEachWithContext(context.Background(), selector, fn, opts...)
+}
+
+// GetEachWithContext is the same as GetEach with the addition of a context.
+func (s *AdGroupCriterionService) GetEachWithContext(ctx context.Context, selector Selector, fn func(interface{}) error, opts ...CallOption) (totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
+	selector.XMLName = xml.Name{Space: baseUrl, Local: "serviceSelector"}
+	return s.Auth.stream(
+		ctx,
+		adGroupCriterionServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Sel     Selector
+		}{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "get",
+			},
+			Sel: selector,
+		},
+		adGroupCriterionEntries(fn),
+	)
+}
+
+// AdGroupCriterionIterator walks the ad group criterions of a selector or
+// query, see AdGroupCriterionService.Iterate.
+type AdGroupCriterionIterator struct {
+	*pageIterator
+}
+
+// AdGroupCriterion returns the current ad group criterion.
+func (it *AdGroupCriterionIterator) AdGroupCriterion() interface{} {
+	return it.page.(AdGroupCriterions)[it.index]
+}
+
+// Iterate returns an iterator over all ad group criterions matching selector, which
+// fetches them page by page, see CampaignService.Iterate.
+func (s AdGroupCriterionService) Iterate(selector Selector, opts ...CallOption) *AdGroupCriterionIterator {
+	return s.IterateWithContext(context.Background(), selector, opts...)
+}
+
+// IterateWithContext is the same as Iterate with the addition of a context,
+// the iteration stops with the error of the context once it is done.
+func (s AdGroupCriterionService) IterateWithContext(ctx context.Context, selector Selector, opts ...CallOption) *AdGroupCriterionIterator {
+	it := &AdGroupCriterionIterator{pageIterator: newPageIterator(ctx, selector, "Id", "AdGroupId")}
+	it.fetch = func(ctx context.Context, selector Selector) (interface{}, int, int64, error) {
+		adGroupCriterions, totalCount, err := s.GetWithContext(ctx, selector, opts...)
+		return adGroupCriterions, len(adGroupCriterions), totalCount, err
+	}
+	it.id = func(i int) int64 { return entityId(it.page.(AdGroupCriterions)[i], "Criterion", "Id") }
+	return it
+}
+
+// IterateQuery returns an iterator over the ad group criterions of an AWQL query without
+// LIMIT clause, see Iterate.  Queries are not split on ids, the iteration
+// stops with ErrPagingLimit past 100,000 entries.
+func (s *AdGroupCriterionService) IterateQuery(query string, opts ...CallOption) *AdGroupCriterionIterator {
+	return s.IterateQueryWithContext(context.Background(), query, opts...)
+}
+
+// IterateQueryWithContext is the same as IterateQuery with the addition of a context.
+func (s *AdGroupCriterionService) IterateQueryWithContext(ctx context.Context, query string, opts ...CallOption) *AdGroupCriterionIterator {
+	it := &AdGroupCriterionIterator{pageIterator: newPageIterator(ctx, Selector{}, "")}
+	it.fetch = queryPage(query, func(ctx context.Context, query string) (interface{}, int, int64, error) {
+		adGroupCriterions, totalCount, err := s.QueryWithContext(ctx, query, opts...)
+		return adGroupCriterions, len(adGroupCriterions), totalCount, err
+	})
+	return it
+}
+
 // Mutate allows you to add, modify and remove ad group criterion, returning the
 // modified ad group criterion.
 //
@@ -207,34 +301,69 @@ type AdGroupCriterionOperation struct {
 	AdGroupCriterion interface{} `xml:"operand"`
 }
 
-func (s *AdGroupCriterionService) MutateOperations(operations []AdGroupCriterionOperation) (adGroupCriterions AdGroupCriterions, err error) {
+func (s *AdGroupCriterionService) MutateOperations(operations []AdGroupCriterionOperation, opts ...CallOption) (adGroupCriterions AdGroupCriterions, err error) {
+	return s.MutateOperationsWithContext(context.Background(), operations, opts...)
+}
 
+// MutateOperationsWithContext is the same as MutateOperations with the addition of a context.
+func (s *AdGroupCriterionService) MutateOperationsWithContext(ctx context.Context, operations []AdGroupCriterionOperation, opts ...CallOption) (adGroupCriterions AdGroupCriterions, err error) {
+	ctx = withCallOptions(ctx, opts)
+	return s.mutateOperations(ctx, operations, nil)
+}
+
+// mutateOperations sends operations, the i'th of them with the exemption
+// requests exemptions[i] if any.
+func (s *AdGroupCriterionService) mutateOperations(ctx context.Context, operations []AdGroupCriterionOperation, exemptions [][]ExemptionRequest) (adGroupCriterions AdGroupCriterions, err error) {
+	type adGroupCriterionOperation struct {
+		Action            string             `xml:"operator"`
+		AdGroupCriterion  interface{}        `xml:"operand"`
+		ExemptionRequests []ExemptionRequest `xml:"exemptionRequests,omitempty"`
+	}
+	ops := []adGroupCriterionOperation{}
+	for i, op := range operations {
+		ops = append(ops,
+			adGroupCriterionOperation{
+				Action:           op.Action,
+				AdGroupCriterion: op.AdGroupCriterion,
+			},
+		)
+		if i < len(exemptions) {
+			ops[i].ExemptionRequests = exemptions[i]
+		}
+	}
 	mutation := struct {
 		XMLName xml.Name
-		Ops     []AdGroupCriterionOperation `xml:"operations"`
+		Ops     []adGroupCriterionOperation `xml:"operations"`
 	}{
 		XMLName: xml.Name{
 			Space: baseUrl,
 			Local: "mutate",
 		},
-		Ops: operations,
+		Ops: ops,
 	}
-	respBody, err := s.Auth.request(adGroupCriterionServiceUrl, "mutate", mutation)
+	respBody, err := s.Auth.request(ctx, adGroupCriterionServiceUrl, "mutate", mutation)
 	if err != nil {
 		return adGroupCriterions, err
 	}
 	mutateResp := struct {
-		AdGroupCriterions AdGroupCriterions `xml:"rval>value"`
+		AdGroupCriterions    AdGroupCriterions    `xml:"rval>value"`
+		PartialFailureErrors partialFailureErrors `xml:"rval>partialFailureErrors"`
 	}{}
 	err = xml.Unmarshal([]byte(respBody), &mutateResp)
 	if err != nil {
 		return adGroupCriterions, err
 	}
 
-	return mutateResp.AdGroupCriterions, err
+	return mutateResp.AdGroupCriterions, partialFailure(operations, mutateResp.PartialFailureErrors)
+}
+
+func (s *AdGroupCriterionService) Mutate(adGroupCriterionOperations AdGroupCriterionOperations, opts ...CallOption) (adGroupCriterions AdGroupCriterions, err error) {
+	return s.MutateWithContext(context.Background(), adGroupCriterionOperations, opts...)
 }
 
-func (s *AdGroupCriterionService) Mutate(adGroupCriterionOperations AdGroupCriterionOperations) (adGroupCriterions AdGroupCriterions, err error) {
+// MutateWithContext is the same as Mutate with the addition of a context.
+func (s *AdGroupCriterionService) MutateWithContext(ctx context.Context, adGroupCriterionOperations AdGroupCriterionOperations, opts ...CallOption) (adGroupCriterions AdGroupCriterions, err error) {
+	ctx = withCallOptions(ctx, opts)
 	operations := []AdGroupCriterionOperation{}
 	for action, adGroupCriterions := range adGroupCriterionOperations {
 		for _, adGroupCriterion := range adGroupCriterions {
@@ -246,7 +375,7 @@ func (s *AdGroupCriterionService) Mutate(adGroupCriterionOperations AdGroupCrite
 			)
 		}
 	}
-	return s.MutateOperations(operations)
+	return s.MutateOperationsWithContext(ctx, operations)
 }
 
 // MutateLabel allows you to add and removes labels from ad groups.
@@ -269,7 +398,13 @@ func (s *AdGroupCriterionService) Mutate(adGroupCriterionOperations AdGroupCrite
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201409/AdGroupCriterionService#mutateLabel
 //
-func (s *AdGroupCriterionService) MutateLabel(adGroupCriterionLabelOperations AdGroupCriterionLabelOperations) (adGroupCriterionLabels []AdGroupCriterionLabel, err error) {
+func (s *AdGroupCriterionService) MutateLabel(adGroupCriterionLabelOperations AdGroupCriterionLabelOperations, opts ...CallOption) (adGroupCriterionLabels []AdGroupCriterionLabel, err error) {
+	return s.MutateLabelWithContext(context.Background(), adGroupCriterionLabelOperations, opts...)
+}
+
+// MutateLabelWithContext is the same as MutateLabel with the addition of a context.
+func (s *AdGroupCriterionService) MutateLabelWithContext(ctx context.Context, adGroupCriterionLabelOperations AdGroupCriterionLabelOperations, opts ...CallOption) (adGroupCriterionLabels []AdGroupCriterionLabel, err error) {
+	ctx = withCallOptions(ctx, opts)
 	type adGroupCriterionLabelOperation struct {
 		Action                string                `xml:"operator"`
 		AdGroupCriterionLabel AdGroupCriterionLabel `xml:"operand"`
@@ -294,19 +429,20 @@ func (s *AdGroupCriterionService) MutateLabel(adGroupCriterionLabelOperations Ad
 			Local: "mutateLabel",
 		},
 		Ops: operations}
-	respBody, err := s.Auth.request(adGroupCriterionServiceUrl, "mutateLabel", mutation)
+	respBody, err := s.Auth.request(ctx, adGroupCriterionServiceUrl, "mutateLabel", mutation)
 	if err != nil {
 		return adGroupCriterionLabels, err
 	}
 	mutateResp := struct {
 		AdGroupCriterionLabels []AdGroupCriterionLabel `xml:"rval>value"`
+		PartialFailureErrors   partialFailureErrors    `xml:"rval>partialFailureErrors"`
 	}{}
 	err = xml.Unmarshal([]byte(respBody), &mutateResp)
 	if err != nil {
 		return adGroupCriterionLabels, err
 	}
 
-	return mutateResp.AdGroupCriterionLabels, err
+	return mutateResp.AdGroupCriterionLabels, partialFailure(operations, mutateResp.PartialFailureErrors)
 }
 
 // Query is not yet implemented
@@ -315,9 +451,16 @@ func (s *AdGroupCriterionService) MutateLabel(adGroupCriterionLabelOperations Ad
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201409/AdGroupCriterionService#query
 //
-func (s *AdGroupCriterionService) Query(query string) (adGroupCriterions AdGroupCriterions, totalCount int64, err error) {
+func (s *AdGroupCriterionService) Query(query string, opts ...CallOption) (adGroupCriterions AdGroupCriterions, totalCount int64, err error) {
+	return s.QueryWithContext(context.Background(), query, opts...)
+}
+
+// QueryWithContext is the same as Query with the addition of a context.
+func (s *AdGroupCriterionService) QueryWithContext(ctx context.Context, query string, opts ...CallOption) (adGroupCriterions AdGroupCriterions, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 
 	respBody, err := s.Auth.request(
+		ctx,
 		adGroupCriterionServiceUrl,
 		"query",
 		AWQLQuery{
@@ -345,3 +488,43 @@ func (s *AdGroupCriterionService) Query(query string) (adGroupCriterions AdGroup
 	return getResp.AdGroupCriterions, getResp.Size, err
 
 }
+
+// QueryEach is the same as Query but passes the criteria to fn one at a time
+// as they are decoded, see GetEach.
+func (s *AdGroupCriterionService) QueryEach(query string, fn func(interface{}) error, opts ...CallOption) (totalCount int64, err error) {
+	return s.QueryEachWithContext(context.Background(), query, fn, opts...)
+}
+
+// QueryEachWithContext is the same as QueryEach with the addition of a context.
+func (s *AdGroupCriterionService) QueryEachWithContext(ctx context.Context, query string, fn func(interface{}) error, opts ...CallOption) (totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
+	return s.Auth.stream(
+		ctx,
+		adGroupCriterionServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+		adGroupCriterionEntries(fn),
+	)
+}
+
+// adGroupCriterionEntries decodes streamed criteria for fn.
+func adGroupCriterionEntries(fn func(interface{}) error) entryFunc {
+	return func(dec *xml.Decoder, start *xml.StartElement) error {
+		var adGroupCriterions AdGroupCriterions
+		if err := dec.DecodeElement(&adGroupCriterions, start); err != nil {
+			return err
+		}
+		for _, adGroupCriterion := range adGroupCriterions {
+			if err := fn(adGroupCriterion); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -1,6 +1,7 @@
 package v201809
 
 import (
+	"context"
 	"encoding/xml"
 )
 
@@ -24,8 +25,15 @@ type AdGroupExtensionSetting struct {
 type AdGroupExtensionSettingOperations map[string][]AdGroupExtensionSetting
 
 // https://developers.google.com/adwords/api/docs/reference/v201809/AdGroupExtensionSettingService#query
-func (s *AdGroupExtensionSettingService) Query(query string) (settings []AdGroupExtensionSetting, totalCount int64, err error) {
+func (s *AdGroupExtensionSettingService) Query(query string, opts ...CallOption) (settings []AdGroupExtensionSetting, totalCount int64, err error) {
+	return s.QueryWithContext(context.Background(), query, opts...)
+}
+
+// QueryWithContext is the same as Query with the addition of a context.
+func (s *AdGroupExtensionSettingService) QueryWithContext(ctx context.Context, query string, opts ...CallOption) (settings []AdGroupExtensionSetting, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 	respBody, err := s.Auth.request(
+		ctx,
 		adGroupExtensionSettingServiceUrl,
 		"query",
 		AWQLQuery{
@@ -53,7 +61,13 @@ func (s *AdGroupExtensionSettingService) Query(query string) (settings []AdGroup
 }
 
 // https://developers.google.com/adwords/api/docs/reference/v201809/AdGroupExtensionSettingService#mutate
-func (s *AdGroupExtensionSettingService) Mutate(settingsOperations AdGroupExtensionSettingOperations) (settings []AdGroupExtensionSetting, err error) {
+func (s *AdGroupExtensionSettingService) Mutate(settingsOperations AdGroupExtensionSettingOperations, opts ...CallOption) (settings []AdGroupExtensionSetting, err error) {
+	return s.MutateWithContext(context.Background(), settingsOperations, opts...)
+}
+
+// MutateWithContext is the same as Mutate with the addition of a context.
+func (s *AdGroupExtensionSettingService) MutateWithContext(ctx context.Context, settingsOperations AdGroupExtensionSettingOperations, opts ...CallOption) (settings []AdGroupExtensionSetting, err error) {
+	ctx = withCallOptions(ctx, opts)
 	type settingOperations struct {
 		Action  string                  `xml:"operator"`
 		Setting AdGroupExtensionSetting `xml:"operand"`
@@ -80,17 +94,18 @@ func (s *AdGroupExtensionSettingService) Mutate(settingsOperations AdGroupExtens
 		Ops: operations,
 	}
 
-	respBody, err := s.Auth.request(adGroupExtensionSettingServiceUrl, "mutate", mutation)
+	respBody, err := s.Auth.request(ctx, adGroupExtensionSettingServiceUrl, "mutate", mutation)
 	if err != nil {
 		return settings, err
 	}
 	mutateResp := struct {
-		Settings []AdGroupExtensionSetting `xml:"rval>value"`
+		Settings             []AdGroupExtensionSetting `xml:"rval>value"`
+		PartialFailureErrors partialFailureErrors      `xml:"rval>partialFailureErrors"`
 	}{}
 	err = xml.Unmarshal(respBody, &mutateResp)
 	if err != nil {
 		return settings, err
 	}
 
-	return mutateResp.Settings, err
+	return mutateResp.Settings, partialFailure(operations, mutateResp.PartialFailureErrors)
 }
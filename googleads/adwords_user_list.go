@@ -1,6 +1,7 @@
 package v201809
 
 import (
+	"context"
 	sha256 "crypto/sha256"
 	"encoding/xml"
 	"fmt"
@@ -236,9 +237,16 @@ func NewMutateMembersOperand() *MutateMembersOperand {
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdwordsUserListService#get
 //
-func (s AdwordsUserListService) Get(selector Selector) (userLists []UserList, err error) {
+func (s AdwordsUserListService) Get(selector Selector, opts ...CallOption) (userLists []UserList, err error) {
+	return s.GetWithContext(context.Background(), selector, opts...)
+}
+
+// GetWithContext is the same as Get with the addition of a context.
+func (s AdwordsUserListService) GetWithContext(ctx context.Context, selector Selector, opts ...CallOption) (userLists []UserList, err error) {
+	ctx = withCallOptions(ctx, opts)
 	selector.XMLName = xml.Name{baseUrl, "serviceSelector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		adwordsUserListServiceUrl,
 		"get",
 		struct {
@@ -259,7 +267,6 @@ func (s AdwordsUserListService) Get(selector Selector) (userLists []UserList, er
 		Size      int64      `xml:"rval>totalNumEntries"`
 		UserLists []UserList `xml:"rval>entries"`
 	}{}
-	fmt.Printf("%s\n", respBody)
 	err = xml.Unmarshal([]byte(respBody), &getResp)
 	if err != nil {
 		return userLists, err
@@ -267,6 +274,35 @@ func (s AdwordsUserListService) Get(selector Selector) (userLists []UserList, er
 	return getResp.UserLists, err
 }
 
+// UserListIterator walks the user lists of a selector, see
+// AdwordsUserListService.Iterate.
+type UserListIterator struct {
+	*pageIterator
+}
+
+// UserList returns the current user list.
+func (it *UserListIterator) UserList() UserList {
+	return it.page.([]UserList)[it.index]
+}
+
+// Iterate returns an iterator over all user lists matching selector, which
+// fetches them page by page, see CampaignService.Iterate.
+func (s AdwordsUserListService) Iterate(selector Selector, opts ...CallOption) *UserListIterator {
+	return s.IterateWithContext(context.Background(), selector, opts...)
+}
+
+// IterateWithContext is the same as Iterate with the addition of a context,
+// the iteration stops with the error of the context once it is done.
+func (s AdwordsUserListService) IterateWithContext(ctx context.Context, selector Selector, opts ...CallOption) *UserListIterator {
+	it := &UserListIterator{pageIterator: newPageIterator(ctx, selector, "Id")}
+	it.fetch = func(ctx context.Context, selector Selector) (interface{}, int, int64, error) {
+		userLists, err := s.GetWithContext(ctx, selector, opts...)
+		return userLists, len(userLists), -1, err
+	}
+	it.id = func(i int) int64 { return it.page.([]UserList)[i].Id }
+	return it
+}
+
 // Mutate adds/sets a collection of user lists. Returns a list of User Lists
 //
 // Example
@@ -289,26 +325,33 @@ func (s AdwordsUserListService) Get(selector Selector) (userLists []UserList, er
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdwordsUserListService#mutate
 //
-func (s *AdwordsUserListService) Mutate(userListOperations UserListOperations) (adwordsUserLists []UserList, err error) {
+func (s *AdwordsUserListService) Mutate(userListOperations UserListOperations, opts ...CallOption) (adwordsUserLists []UserList, err error) {
+	return s.MutateWithContext(context.Background(), userListOperations, opts...)
+}
+
+// MutateWithContext is the same as Mutate with the addition of a context.
+func (s *AdwordsUserListService) MutateWithContext(ctx context.Context, userListOperations UserListOperations, opts ...CallOption) (adwordsUserLists []UserList, err error) {
+	ctx = withCallOptions(ctx, opts)
 
 	userListOperations.XMLName = xml.Name{
 		Space: baseRemarketingUrl,
 		Local: "mutate",
 	}
 
-	respBody, err := s.Auth.request(adwordsUserListServiceUrl, "mutate", userListOperations)
+	respBody, err := s.Auth.request(ctx, adwordsUserListServiceUrl, "mutate", userListOperations)
 	if err != nil {
 		return adwordsUserLists, err
 	}
 	mutateResp := struct {
-		AdwordsUserLists []UserList `xml:"rval>value"`
+		AdwordsUserLists     []UserList           `xml:"rval>value"`
+		PartialFailureErrors partialFailureErrors `xml:"rval>partialFailureErrors"`
 	}{}
 	err = xml.Unmarshal([]byte(respBody), &mutateResp)
 	if err != nil {
 		return adwordsUserLists, err
 	}
 
-	return mutateResp.AdwordsUserLists, err
+	return mutateResp.AdwordsUserLists, partialFailure(userListOperations.Operations, mutateResp.PartialFailureErrors)
 }
 
 // Mutate adds/removes members/emails to specified user list.
@@ -339,25 +382,32 @@ func (s *AdwordsUserListService) Mutate(userListOperations UserListOperations) (
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdwordsUserListService#mutateMembers
 //
-func (s *AdwordsUserListService) MutateMembers(mutateMembersOperations MutateMembersOperations) (adwordsUserLists []UserList, err error) {
+func (s *AdwordsUserListService) MutateMembers(mutateMembersOperations MutateMembersOperations, opts ...CallOption) (adwordsUserLists []UserList, err error) {
+	return s.MutateMembersWithContext(context.Background(), mutateMembersOperations, opts...)
+}
+
+// MutateMembersWithContext is the same as MutateMembers with the addition of a context.
+func (s *AdwordsUserListService) MutateMembersWithContext(ctx context.Context, mutateMembersOperations MutateMembersOperations, opts ...CallOption) (adwordsUserLists []UserList, err error) {
+	ctx = withCallOptions(ctx, opts)
 	mutateMembersOperations.XMLName = xml.Name{
 		Space: baseRemarketingUrl,
 		Local: "mutateMembers",
 	}
 
-	respBody, err := s.Auth.request(adwordsUserListServiceUrl, "mutateMembers", mutateMembersOperations)
+	respBody, err := s.Auth.request(ctx, adwordsUserListServiceUrl, "mutateMembers", mutateMembersOperations)
 	if err != nil {
 		return adwordsUserLists, err
 	}
 	mutateResp := struct {
-		AdwordsUserLists []UserList `xml:"rval>userLists"`
+		AdwordsUserLists     []UserList           `xml:"rval>userLists"`
+		PartialFailureErrors partialFailureErrors `xml:"rval>partialFailureErrors"`
 	}{}
 	err = xml.Unmarshal([]byte(respBody), &mutateResp)
 	if err != nil {
 		return adwordsUserLists, err
 	}
 
-	return mutateResp.AdwordsUserLists, err
+	return mutateResp.AdwordsUserLists, partialFailure(mutateMembersOperations.Operations, mutateResp.PartialFailureErrors)
 }
 
 func (mmo MutateMembersOperand) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
@@ -0,0 +1,527 @@
+package v201809
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Query is an AWQL query, the text form of a Selector which reports add a
+// FROM clause to.  Select builds one, ParseQuery reads one and String
+// writes it.
+//
+// Example
+//
+//   query := gads.Select("Id", "Name").
+//     Where("Status", gads.OperatorIn, "ENABLED", "PAUSED").
+//     OrderBy("Name").
+//     Limit(0, 100)
+//   campaigns, totalCount, err := campaignService.Query(query.String())
+//
+type Query struct {
+	Fields        []string
+	Report        string      // FROM clause, only for reports
+	Predicates    []Predicate // WHERE clause, joined by AND
+	DateRange     *DateRange  // DURING min,max
+	DateRangeType string      // DURING a predefined range like LAST_7_DAYS
+	Ordering      []OrderBy
+	Paging        *Paging // LIMIT offset,count
+}
+
+// Select returns a query of fields.
+func Select(fields ...string) *Query {
+	return &Query{Fields: fields}
+}
+
+// SelectorQuery returns the query of selector.
+func SelectorQuery(selector Selector) *Query {
+	q := &Query{
+		Fields:     append([]string(nil), selector.Fields...),
+		Predicates: append([]Predicate(nil), selector.Predicates...),
+		Ordering:   append([]OrderBy(nil), selector.Ordering...),
+	}
+	if selector.DateRange != nil {
+		dr := *selector.DateRange
+		q.DateRange = &dr
+	}
+	if selector.Paging != nil {
+		p := *selector.Paging
+		q.Paging = &p
+	}
+	return q
+}
+
+// From sets the report type queried, e.g. CAMPAIGN_PERFORMANCE_REPORT.
+func (q *Query) From(report string) *Query {
+	q.Report = report
+	return q
+}
+
+// Where adds a condition on field.
+func (q *Query) Where(field string, op Operator, values ...string) *Query {
+	q.Predicates = append(q.Predicates, Predicate{Field: field, Operator: string(op), Values: values})
+	return q
+}
+
+// During restricts the query to the dates from min to max, formatted as
+// YYYYMMDD.
+func (q *Query) During(min, max string) *Query {
+	q.DateRange, q.DateRangeType = &DateRange{Min: min, Max: max}, ""
+	return q
+}
+
+// DuringRange restricts the query to a predefined date range, like
+// LAST_7_DAYS or THIS_MONTH.
+func (q *Query) DuringRange(dateRangeType string) *Query {
+	q.DateRange, q.DateRangeType = nil, dateRangeType
+	return q
+}
+
+func (q *Query) OrderBy(field string) *Query {
+	q.Ordering = append(q.Ordering, OrderBy{Field: field, SortOrder: "ASCENDING"})
+	return q
+}
+
+func (q *Query) OrderByDesc(field string) *Query {
+	q.Ordering = append(q.Ordering, OrderBy{Field: field, SortOrder: "DESCENDING"})
+	return q
+}
+
+func (q *Query) Limit(offset, count int64) *Query {
+	q.Paging = &Paging{Offset: offset, Limit: count}
+	return q
+}
+
+// symbolOperators are the operators AWQL writes as symbols, the others are
+// written by name.
+var symbolOperators = map[Operator]string{
+	OperatorEquals:            "=",
+	OperatorNotEquals:         "!=",
+	OperatorGreaterThan:       ">",
+	OperatorGreaterThanEquals: ">=",
+	OperatorLessThan:          "<",
+	OperatorLessThanEquals:    "<=",
+}
+
+// quoteAWQL quotes an AWQL string literal.
+func quoteAWQL(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// String returns the query in AWQL.
+func (q *Query) String() string {
+	var b strings.Builder
+	b.WriteString("SELECT " + strings.Join(q.Fields, ", "))
+	if q.Report != "" {
+		b.WriteString(" FROM " + q.Report)
+	}
+	for i, p := range q.Predicates {
+		if i == 0 {
+			b.WriteString(" WHERE ")
+		} else {
+			b.WriteString(" AND ")
+		}
+		op := Operator(p.Operator)
+		if sym, ok := symbolOperators[op]; ok {
+			b.WriteString(p.Field + " " + sym + " ")
+		} else {
+			b.WriteString(p.Field + " " + p.Operator + " ")
+		}
+		values := make([]string, len(p.Values))
+		for j, v := range p.Values {
+			values[j] = quoteAWQL(v)
+		}
+		if op.multiValued() {
+			b.WriteString("[" + strings.Join(values, ", ") + "]")
+		} else {
+			b.WriteString(strings.Join(values, ", "))
+		}
+	}
+	if q.DateRange != nil {
+		b.WriteString(" DURING " + q.DateRange.Min + "," + q.DateRange.Max)
+	} else if q.DateRangeType != "" {
+		b.WriteString(" DURING " + q.DateRangeType)
+	}
+	for i, o := range q.Ordering {
+		if i == 0 {
+			b.WriteString(" ORDER BY ")
+		} else {
+			b.WriteString(", ")
+		}
+		b.WriteString(o.Field)
+		if o.SortOrder == "DESCENDING" {
+			b.WriteString(" DESC")
+		}
+	}
+	if q.Paging != nil {
+		fmt.Fprintf(&b, " LIMIT %d,%d", q.Paging.Offset, q.Paging.Limit)
+	}
+	return b.String()
+}
+
+// Selector returns the selector of a query of a service.  Predefined date
+// ranges and the FROM clause of reports have no selector equivalent.
+func (q *Query) Selector() (Selector, error) {
+	if q.Report != "" {
+		return Selector{}, fmt.Errorf("gads: query of report %s has no selector", q.Report)
+	}
+	if q.DateRangeType != "" {
+		return Selector{}, fmt.Errorf("gads: date range %s has no selector equivalent", q.DateRangeType)
+	}
+	return q.selector(), nil
+}
+
+func (q *Query) selector() Selector {
+	return Selector{Fields: q.Fields, Predicates: q.Predicates, DateRange: q.DateRange, Ordering: q.Ordering, Paging: q.Paging}
+}
+
+// Validate checks a query of service against its catalog in
+// SelectorFields, see ValidateSelector.
+func (q *Query) Validate(service string) error {
+	if q.Report != "" {
+		return &InvalidSelectorError{Service: service, Problems: []string{"FROM is only allowed in report queries"}}
+	}
+	return ValidateSelector(service, q.selector())
+}
+
+// ValidateReport checks a report query against the fields of the report
+// returned by ReportDefinitionService.GetReportFields.  Besides the checks
+// of ValidateSelector, values of enum fields must be known.
+func (q *Query) ValidateReport(fields []ReportDefinitionField) error {
+	name := q.Report
+	if name == "" {
+		name = "report"
+	}
+	catalog := FieldCatalog{}
+	enums := map[string][]string{}
+	for _, f := range fields {
+		if f.CanSelect {
+			catalog.Selectable = append(catalog.Selectable, f.FieldName)
+		}
+		if f.CanFilter {
+			catalog.Filterable = append(catalog.Filterable, f.FieldName)
+		}
+		if f.IsEnumType {
+			enums[f.FieldName] = f.EnumValues
+		}
+	}
+
+	err := validateSelector(name, q.selector(), catalog)
+	ise, _ := err.(*InvalidSelectorError)
+	if ise == nil {
+		ise = &InvalidSelectorError{Service: name}
+	}
+	if q.Report == "" {
+		ise.Problems = append(ise.Problems, "no report in the FROM clause")
+	}
+	for _, p := range q.Predicates {
+		values, ok := enums[p.Field]
+		if !ok {
+			continue
+		}
+		for _, v := range p.Values {
+			if !hasField(values, v) {
+				ise.Problems = append(ise.Problems, fmt.Sprintf("%q is not a value of %q", v, p.Field))
+			}
+		}
+	}
+	if len(ise.Problems) > 0 {
+		return ise
+	}
+	return nil
+}
+
+// AWQLSyntaxError is returned by ParseQuery for malformed queries.
+type AWQLSyntaxError struct {
+	Query  string
+	Offset int // in bytes
+	Msg    string
+}
+
+func (e *AWQLSyntaxError) Error() string {
+	return fmt.Sprintf("gads: awql: %s at offset %d of %q", e.Msg, e.Offset, e.Query)
+}
+
+// awqlToken is a token of an AWQL query.
+type awqlToken struct {
+	kind   byte // 'i'dentifier, 's'tring, 'n'umber, 'p'unctuation or 0 at the end
+	text   string
+	offset int
+}
+
+// lexAWQL splits query into tokens, strings are unquoted.
+func lexAWQL(query string) ([]awqlToken, error) {
+	tokens := []awqlToken{}
+	for i := 0; i < len(query); {
+		c := query[i]
+		start := i
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case c == '\'' || c == '"':
+			var b strings.Builder
+			i++
+			for ; i < len(query) && query[i] != c; i++ {
+				if query[i] == '\\' && i+1 < len(query) {
+					i++
+				}
+				b.WriteByte(query[i])
+			}
+			if i == len(query) {
+				return nil, &AWQLSyntaxError{Query: query, Offset: start, Msg: "unterminated string"}
+			}
+			i++
+			tokens = append(tokens, awqlToken{'s', b.String(), start})
+			continue
+		case c == '-' || (c >= '0' && c <= '9'):
+			for i++; i < len(query) && (query[i] == '.' || (query[i] >= '0' && query[i] <= '9')); i++ {
+			}
+			tokens = append(tokens, awqlToken{'n', query[start:i], start})
+			continue
+		case c == '_' || unicode.IsLetter(rune(c)):
+			for i++; i < len(query) && (query[i] == '_' || query[i] == '.' || unicode.IsLetter(rune(query[i])) || unicode.IsDigit(rune(query[i]))); i++ {
+			}
+			tokens = append(tokens, awqlToken{'i', query[start:i], start})
+			continue
+		case strings.HasPrefix(query[i:], "!=") || strings.HasPrefix(query[i:], ">=") || strings.HasPrefix(query[i:], "<="):
+			i += 2
+		case strings.IndexByte(",[]()=<>", c) >= 0:
+			i++
+		default:
+			return nil, &AWQLSyntaxError{Query: query, Offset: i, Msg: fmt.Sprintf("unexpected %q", c)}
+		}
+		tokens = append(tokens, awqlToken{'p', query[start:i], start})
+	}
+	return append(tokens, awqlToken{offset: len(query)}), nil
+}
+
+// awqlParser reads tokens into a Query.
+type awqlParser struct {
+	query  string
+	tokens []awqlToken
+	pos    int
+}
+
+func (p *awqlParser) peek() awqlToken {
+	return p.tokens[p.pos]
+}
+
+func (p *awqlParser) next() awqlToken {
+	t := p.tokens[p.pos]
+	if t.kind != 0 {
+		p.pos++
+	}
+	return t
+}
+
+// keyword consumes the next token if it is the keyword kw.
+func (p *awqlParser) keyword(kw string) bool {
+	if t := p.peek(); t.kind == 'i' && strings.EqualFold(t.text, kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// punct consumes the next token if it is s.
+func (p *awqlParser) punct(s string) bool {
+	if t := p.peek(); t.kind == 'p' && t.text == s {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *awqlParser) errorf(format string, args ...interface{}) error {
+	return &AWQLSyntaxError{Query: p.query, Offset: p.peek().offset, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *awqlParser) ident(what string) (string, error) {
+	if t := p.peek(); t.kind == 'i' {
+		p.pos++
+		return t.text, nil
+	}
+	return "", p.errorf("expected %s", what)
+}
+
+func (p *awqlParser) number() (int64, error) {
+	t := p.peek()
+	n, err := strconv.ParseInt(t.text, 10, 64)
+	if t.kind != 'n' || err != nil {
+		return 0, p.errorf("expected a number")
+	}
+	p.pos++
+	return n, nil
+}
+
+// value reads a string, a number or a bare word like ENABLED.
+func (p *awqlParser) value() (string, error) {
+	if t := p.peek(); t.kind == 's' || t.kind == 'n' || t.kind == 'i' {
+		p.pos++
+		return t.text, nil
+	}
+	return "", p.errorf("expected a value")
+}
+
+// operator reads the operator of a condition.
+func (p *awqlParser) operator() (Operator, error) {
+	t := p.peek()
+	if t.kind == 'p' {
+		for op, sym := range symbolOperators {
+			if sym == t.text {
+				p.pos++
+				return op, nil
+			}
+		}
+	}
+	if op := Operator(strings.ToUpper(t.text)); t.kind == 'i' && op.valid() {
+		if _, ok := symbolOperators[op]; !ok {
+			p.pos++
+			return op, nil
+		}
+	}
+	return "", p.errorf("expected an operator")
+}
+
+func (p *awqlParser) predicate() (Predicate, error) {
+	field, err := p.ident("a field")
+	if err != nil {
+		return Predicate{}, err
+	}
+	op, err := p.operator()
+	if err != nil {
+		return Predicate{}, err
+	}
+	pred := Predicate{Field: field, Operator: string(op)}
+	if !op.multiValued() {
+		v, err := p.value()
+		pred.Values = []string{v}
+		return pred, err
+	}
+	closing := "]"
+	if p.punct("(") {
+		closing = ")"
+	} else if !p.punct("[") {
+		return pred, p.errorf("expected a list of values")
+	}
+	for {
+		v, err := p.value()
+		if err != nil {
+			return pred, err
+		}
+		pred.Values = append(pred.Values, v)
+		if p.punct(closing) {
+			return pred, nil
+		}
+		if !p.punct(",") {
+			return pred, p.errorf("expected , or %s", closing)
+		}
+	}
+}
+
+// ParseQuery parses an AWQL query, of a service or of a report.  Keywords
+// are case insensitive and values may be quoted with ' or ".
+func ParseQuery(query string) (*Query, error) {
+	tokens, err := lexAWQL(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &awqlParser{query: query, tokens: tokens}
+	q := &Query{}
+
+	if !p.keyword("SELECT") {
+		return nil, p.errorf("expected SELECT")
+	}
+	for {
+		field, err := p.ident("a field")
+		if err != nil {
+			return nil, err
+		}
+		q.Fields = append(q.Fields, field)
+		if !p.punct(",") {
+			break
+		}
+	}
+
+	if p.keyword("FROM") {
+		if q.Report, err = p.ident("a report"); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.keyword("WHERE") {
+		for {
+			pred, err := p.predicate()
+			if err != nil {
+				return nil, err
+			}
+			q.Predicates = append(q.Predicates, pred)
+			if !p.keyword("AND") {
+				break
+			}
+		}
+	}
+
+	if p.keyword("DURING") {
+		if t := p.peek(); t.kind == 'i' {
+			q.DateRangeType = strings.ToUpper(p.next().text)
+		} else {
+			min, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			if !p.punct(",") {
+				return nil, p.errorf("expected ,")
+			}
+			max, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			q.DateRange = &DateRange{Min: min, Max: max}
+		}
+	}
+
+	if p.keyword("ORDER") {
+		if !p.keyword("BY") {
+			return nil, p.errorf("expected BY")
+		}
+		for {
+			field, err := p.ident("a field")
+			if err != nil {
+				return nil, err
+			}
+			order := OrderBy{Field: field, SortOrder: "ASCENDING"}
+			if p.keyword("DESC") {
+				order.SortOrder = "DESCENDING"
+			} else {
+				p.keyword("ASC")
+			}
+			q.Ordering = append(q.Ordering, order)
+			if !p.punct(",") {
+				break
+			}
+		}
+	}
+
+	if p.keyword("LIMIT") {
+		offset, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		if !p.punct(",") {
+			return nil, p.errorf("expected ,")
+		}
+		count, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		q.Paging = &Paging{Offset: offset, Limit: count}
+	}
+
+	if t := p.peek(); t.kind != 0 {
+		return nil, p.errorf("unexpected %q", t.text)
+	}
+	return q, nil
+}
@@ -0,0 +1,122 @@
+package v201809
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestQueryString(t *testing.T) {
+	q := Select("CampaignId", "Clicks").
+		From("CAMPAIGN_PERFORMANCE_REPORT").
+		Where("CampaignStatus", OperatorIn, "ENABLED", "PAUSED").
+		Where("CampaignName", OperatorStartsWith, `Brand "A"`).
+		Where("Clicks", OperatorGreaterThan, "10").
+		During("20180101", "20180131").
+		OrderByDesc("Clicks").
+		Limit(0, 100)
+	expected := `SELECT CampaignId, Clicks FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus IN ["ENABLED", "PAUSED"] AND CampaignName STARTS_WITH "Brand \"A\"" AND Clicks > "10" DURING 20180101,20180131 ORDER BY Clicks DESC LIMIT 0,100`
+	if q.String() != expected {
+		t.Errorf("got\n%s", q.String())
+	}
+
+	parsed, err := ParseQuery(q.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, q) {
+		t.Errorf("got %#v", parsed)
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	q, err := ParseQuery(`select Id, Name where Status in ('ENABLED','PAUSED') and Id >= 5 and Labels CONTAINS_ANY [1, 2] during LAST_7_DAYS order by Name asc, Id desc`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &Query{
+		Fields: []string{"Id", "Name"},
+		Predicates: []Predicate{
+			{"Status", "IN", []string{"ENABLED", "PAUSED"}},
+			{"Id", "GREATER_THAN_EQUALS", []string{"5"}},
+			{"Labels", "CONTAINS_ANY", []string{"1", "2"}},
+		},
+		DateRangeType: "LAST_7_DAYS",
+		Ordering:      []OrderBy{{"Name", "ASCENDING"}, {"Id", "DESCENDING"}},
+	}
+	if !reflect.DeepEqual(q, expected) {
+		t.Errorf("got %#v", q)
+	}
+
+	for query, offset := range map[string]int{
+		"Id, Name":                          0,
+		"SELECT Id WHERE":                   15,
+		"SELECT Id WHERE Name = 'a":         23,
+		"SELECT Id WHERE Id IN 1":           22,
+		"SELECT Id WHERE Id LIKE 1":         19,
+		"SELECT Id ORDER BY Id LIMIT 10":    30,
+		"SELECT Id DURING 20180101 LIMIT 1": 26,
+		"SELECT Id; DROP":                   9,
+	} {
+		_, err := ParseQuery(query)
+		var se *AWQLSyntaxError
+		if !errors.As(err, &se) {
+			t.Errorf("%s: expected a syntax error, got %v", query, err)
+		} else if se.Offset != offset {
+			t.Errorf("%s: got %v", query, err)
+		}
+	}
+}
+
+func TestQuerySelector(t *testing.T) {
+	selector := NewSelector("Id", "Name").Equals("Status", "ENABLED").OrderBy("Name").Paging(10, 5).Selector()
+	q := SelectorQuery(selector)
+	if s := q.String(); s != `SELECT Id, Name WHERE Status = "ENABLED" ORDER BY Name LIMIT 10,5` {
+		t.Errorf("got %s", s)
+	}
+	back, err := q.Selector()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(back, selector) {
+		t.Errorf("got %#v", back)
+	}
+	if err := q.Validate("CampaignService"); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := Select("Id").DuringRange("LAST_7_DAYS").Selector(); err == nil {
+		t.Error("expected predefined date ranges to have no selector")
+	}
+	if err := Select("Id").From("CAMPAIGN_PERFORMANCE_REPORT").Validate("CampaignService"); err == nil {
+		t.Error("expected FROM to be rejected in service queries")
+	}
+}
+
+func TestQueryValidateReport(t *testing.T) {
+	fields := []ReportDefinitionField{
+		{FieldName: "CampaignId", CanSelect: true, CanFilter: true},
+		{FieldName: "CampaignStatus", CanSelect: true, CanFilter: true, IsEnumType: true, EnumValues: []string{"ENABLED", "PAUSED", "REMOVED"}},
+		{FieldName: "Clicks", CanSelect: true},
+	}
+	q := Select("CampaignId", "Clicks").From("CAMPAIGN_PERFORMANCE_REPORT").Where("CampaignStatus", OperatorEquals, "ENABLED")
+	if err := q.ValidateReport(fields); err != nil {
+		t.Error(err)
+	}
+
+	q = Select("CampaignId", "Cost").Where("Clicks", OperatorGreaterThan, "1").Where("CampaignStatus", OperatorIn, "ACTIVE")
+	err := q.ValidateReport(fields)
+	var ise *InvalidSelectorError
+	if !errors.As(err, &ise) {
+		t.Fatalf("expected an InvalidSelectorError, got %v", err)
+	}
+	expected := []string{
+		`field "Cost" is not selectable`,
+		`field "Clicks" is not filterable`,
+		"no report in the FROM clause",
+		`"ACTIVE" is not a value of "CampaignStatus"`,
+	}
+	if !reflect.DeepEqual(ise.Problems, expected) {
+		t.Errorf("got %q", ise.Problems)
+	}
+}
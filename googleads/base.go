@@ -1,32 +1,34 @@
 package v201809
 
 import (
-	"bytes"
-	"compress/gzip"
+	"context"
 	"encoding/xml"
-	"errors"
 	"fmt"
-	"io"
-	"io/ioutil"
 	"net/http"
-	"os"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 )
 
 const (
+	// DefaultEndpoint is the root of all service and report download urls.
+	// It also prefixes the XML namespaces, which stay unchanged when requests
+	// are sent elsewhere using Auth.Endpoint.
+	DefaultEndpoint = "https://adwords.google.com/api/adwords"
+
 	version               = "v201809"
-	rootUrl               = "https://adwords.google.com/api/adwords/cm/"
-	baseUrl               = "https://adwords.google.com/api/adwords/cm/" + version
-	rootMcmUrl            = "https://adwords.google.com/api/adwords/mcm/"
-	baseMcmUrl            = "https://adwords.google.com/api/adwords/mcm/" + version
-	rootRemarketingUrl    = "https://adwords.google.com/api/adwords/rm/"
-	baseRemarketingUrl    = "https://adwords.google.com/api/adwords/rm/" + version
-	rootReportDownloadUrl = "https://adwords.google.com/api/adwords/reportdownload/"
-	baseReportDownloadUrl = "https://adwords.google.com/api/adwords/reportdownload/" + version
-	rootTrafficUrl        = "https://adwords.google.com/api/adwords/o/"
-	baseTrafficUrl        = "https://adwords.google.com/api/adwords/o/" + version
-	baseSyncUrl           = "https://adwords.google.com/api/adwords/ch/" + version
+	rootUrl               = DefaultEndpoint + "/cm/"
+	baseUrl               = DefaultEndpoint + "/cm/" + version
+	rootMcmUrl            = DefaultEndpoint + "/mcm/"
+	baseMcmUrl            = DefaultEndpoint + "/mcm/" + version
+	rootRemarketingUrl    = DefaultEndpoint + "/rm/"
+	baseRemarketingUrl    = DefaultEndpoint + "/rm/" + version
+	rootReportDownloadUrl = DefaultEndpoint + "/reportdownload/"
+	baseReportDownloadUrl = DefaultEndpoint + "/reportdownload/" + version
+	rootTrafficUrl        = DefaultEndpoint + "/o/"
+	baseTrafficUrl        = DefaultEndpoint + "/o/" + version
+	baseSyncUrl           = DefaultEndpoint + "/ch/" + version
 )
 
 type ServiceUrl struct {
@@ -100,6 +102,75 @@ type Auth struct {
 	ValidateOnly   bool
 	Testing        *testing.T `json:"-"`
 	Client         HttpClient `json:"-"`
+
+	// RetryPolicy decides which failed requests are retried, the
+	// DefaultRetryPolicy is used when nil.  It applies to report downloads
+	// too, which earlier releases never retried; set NoRetry to keep them
+	// to a single attempt.
+	RetryPolicy RetryPolicy `json:"-"`
+
+	// RateLimiter, when set, throttles requests before they are sent.
+	RateLimiter RateLimiter `json:"-"`
+
+	// ResponseHeaderHandler, when set, is called with the header of every
+	// SOAP response, e.g. to account for the operations used.
+	ResponseHeaderHandler func(ResponseHeader) `json:"-"`
+
+	// Interceptors wrap every exchange with the API, including report
+	// downloads and batch job transfers.  The first one is the outermost.
+	Interceptors []Interceptor `json:"-"`
+
+	// Logger receives an event for every request, when nil events are
+	// printed to stdout if the DEBUG environment variable is set.
+	Logger Logger `json:"-"`
+
+	// LogBodies adds redacted headers and bodies to the logged events.
+	LogBodies bool `json:"-"`
+
+	// Endpoint replaces DefaultEndpoint as the root requests are sent to,
+	// e.g. "http://localhost:8080/api/adwords" for a fake server.
+	Endpoint string `json:",omitempty"`
+
+	// BatchJobEndpoint replaces scheme and host of the temporary batch job
+	// upload and download urls, e.g. "http://localhost:8080".
+	BatchJobEndpoint string `json:",omitempty"`
+
+	// Cache, when set, stores the responses to the read actions, e.g. get
+	// and query, enabled in CacheTTL.  Mutations are never cached.
+	Cache Cache `json:"-"`
+
+	// CacheTTL tells for how long responses are cached by
+	// "Service.action", e.g. "CampaignService.get", by "Service" for all
+	// read actions of the service or by "" for all read actions.  Actions
+	// without a positive TTL are not cached.
+	CacheTTL map[string]time.Duration `json:"-"`
+}
+
+// endpointUrl returns the url requests for the service are sent to.
+func (a *Auth) endpointUrl(s ServiceUrl) string {
+	u := s.String()
+	if a.Endpoint != "" && strings.HasPrefix(u, DefaultEndpoint) {
+		return strings.TrimSuffix(a.Endpoint, "/") + strings.TrimPrefix(u, DefaultEndpoint)
+	}
+	return u
+}
+
+// batchJobUrl moves a temporary batch job url to a.BatchJobEndpoint.
+func (a *Auth) batchJobUrl(u string) (string, error) {
+	if a.BatchJobEndpoint == "" {
+		return u, nil
+	}
+	endpoint, err := url.Parse(a.BatchJobEndpoint)
+	if err != nil {
+		return "", err
+	}
+	temporary, err := url.Parse(u)
+	if err != nil {
+		return "", err
+	}
+	temporary.Scheme = endpoint.Scheme
+	temporary.Host = endpoint.Host
+	return temporary.String(), nil
 }
 
 type HttpClient interface {
@@ -177,57 +248,114 @@ func selectorError() (err error) {
 	return err
 }
 
-func (a *Auth) do(serviceUrl ServiceUrl, action string, body, ret interface{}) error {
-	raw, err := a.doRequest(serviceUrl, action, body)
+func (a *Auth) do(ctx context.Context, serviceUrl ServiceUrl, action string, body, ret interface{}) error {
+	raw, err := a.doRequest(ctx, serviceUrl, action, body)
 	if err != nil {
 		return err
 	}
 
-	if err = xml.Unmarshal([]byte(raw), &ret); err != nil {
-		return err
-	}
-
-	if level := os.Getenv("DEBUG"); level != "" {
-		if resBody, err := xml.MarshalIndent(ret, "  ", "  "); err != nil {
-			fmt.Println("warn: ", err)
-		} else {
-			fmt.Printf("response->\n%s\n", string(resBody))
-		}
-	}
+	return xml.Unmarshal([]byte(raw), &ret)
+}
 
-	return nil
+func (a *Auth) request(ctx context.Context, serviceUrl ServiceUrl, action string, body interface{}) (respBody []byte, err error) {
+	return a.doRequest(ctx, serviceUrl, action, body)
 }
 
-func (a *Auth) request(serviceUrl ServiceUrl, action string, body interface{}) (respBody []byte, err error) {
-	return a.doRequest(serviceUrl, action, body)
+func (a *Auth) retryPolicy() RetryPolicy {
+	if a.RetryPolicy != nil {
+		return a.RetryPolicy
+	}
+	return DefaultRetryPolicy
 }
 
-var (
-	tokenForCache string
-)
+func (a *Auth) doRequest(ctx context.Context, serviceUrl ServiceUrl, action string, body interface{}) (respBody []byte, err error) {
+	attempts := 0
+	err = a.withRetry(ctx, func() (bool, error) {
+		if attempts++; attempts > 1 {
+			stat.retry(serviceUrl.Name, action)
+		}
+		respBody, err = a.doRequestFunc(ctx, serviceUrl, action, body)
+		return true, err
+	})
+	return respBody, err
+}
 
-func SetCacheToken(t string) {
-	tokenForCache = t
+// withRetry runs attempt until it succeeds, the retry policy gives up or
+// attempt reports that it must not be repeated.
+func (a *Auth) withRetry(ctx context.Context, attempt func() (retryable bool, err error)) error {
+	policy := a.retryPolicy()
+	for n := 1; ; n++ {
+		retryable, err := attempt()
+		if err == nil {
+			return nil
+		}
+		a.observeRateExceeded(ctx, err)
+		// a cancelled or expired context is never worth retrying
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !retryable {
+			return err
+		}
+		delay, ok := policy.Retry(n, err)
+		if !ok {
+			return err
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
 }
 
-func (a *Auth) doRequest(serviceUrl ServiceUrl, action string, body interface{}) (respBody []byte, err error) {
-	timeout := time.Second * 5
-	retries := 4
-retry:
-	result, err := a.doRequestFunc(serviceUrl, action, body)
-	if err != nil && retries > 0 {
-		retries--
-		time.Sleep(timeout)
-		//timeout += 5 * time.Second
-		goto retry
+// sleepContext pauses for d or until ctx is done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
 	}
-	return result, err
 }
 
-func (a *Auth) doRequestFunc(serviceUrl ServiceUrl, action string, body interface{}) (respBody []byte, err error) {
+func (a *Auth) doRequestFunc(ctx context.Context, serviceUrl ServiceUrl, action string, body interface{}) (respBody []byte, err error) {
 
 	startTime := time.Now()
 
+	ex, err := a.soapExchange(ctx, serviceUrl, action, body)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	err = a.intercept(ctx, ex, a.soapHandler)
+	// an interceptor may have answered the exchange itself or replaced the
+	// response, passing on the error of the former one
+	if (err == nil || err == ex.Err) && ex.undecoded() {
+		decodeSoapResponse(ex)
+		err = ex.Err
+	}
+	a.logExchange(ex, time.Since(startTime), err)
+
+	_, mem := a.Cache.(*LRUCache)
+	stat.count(serviceUrl.Name, action, ex.Cached, ex.Cached && mem, time.Since(startTime), err)
+	if ex.StatusCode == 0 {
+		return []byte{}, err
+	}
+
+	if a.Testing != nil {
+		a.Testing.Logf("respBody ->\n%s\n%s\n", string(ex.ResponseBody), fmt.Sprintf("%d", ex.StatusCode))
+	}
+
+	if ex.SoapHeader.RequestId != "" {
+		a.handleResponseHeader(ctx, ex.SoapHeader)
+	}
+	return ex.result, err
+}
+
+// soapExchange wraps body in a SOAP envelope addressed to action of
+// serviceUrl, applying the call options of ctx to its header.
+func (a *Auth) soapExchange(ctx context.Context, serviceUrl ServiceUrl, action string, body interface{}) (*Exchange, error) {
 	type devToken struct {
 		XMLName xml.Name
 	}
@@ -254,14 +382,14 @@ func (a *Auth) doRequestFunc(serviceUrl ServiceUrl, action string, body interfac
 		XMLName:          xml.Name{serviceUrl.Url, "RequestHeader"},
 		UserAgent:        a.UserAgent,
 		DeveloperToken:   a.DeveloperToken,
-		ClientCustomerId: a.CustomerId,
+		ClientCustomerId: a.customerId(ctx),
 	}
 
 	// https://developers.google.com/adwords/api/docs/guides/partial-failure
-	if a.PartialFailure {
+	if a.partialFailure(ctx) {
 		reqHead.PartialFailure = true
 	}
-	if a.ValidateOnly {
+	if a.validateOnly(ctx) {
 		reqHead.ValidateOnly = true
 	}
 
@@ -273,132 +401,25 @@ func (a *Auth) doRequestFunc(serviceUrl ServiceUrl, action string, body interfac
 		},
 		"  ", "  ")
 	if err != nil {
-		return []byte{}, err
-	}
-
-	// load cache
-	cacheResp, ok := []byte{}, false
-	if cache_ENABLED {
-		cacheResp, ok = cache.Get([]string{
-			serviceUrl.String(),
-			tokenForCache,
-			action,
-			string(reqBody),
-		})
-	}
-
-	respStatusCode := 0
-
-	if ok && cache_ENABLED {
-		respBody = cacheResp
-		respStatusCode = 200
-	} else {
-		req, err := http.NewRequest("POST", serviceUrl.String(), bytes.NewReader(reqBody))
-		req.Header.Add("Accept", "text/xml")
-		req.Header.Add("User-Agent", "gads (gzip)")
-		req.Header.Add("Accept-Encoding", "gzip")
-		req.Header.Add("Accept", "multipart/*")
-		req.Header.Add("Content-Type", "text/xml;charset=UTF-8")
-		contentLength := fmt.Sprintf("%d", len(reqBody))
-		req.Header.Add("Content-length", contentLength)
-		req.Header.Add("SOAPAction", action)
-		//if a.Testing != nil {
-		//	a.Testing.Logf("request ->\n%s\n%#v\n%s\n", req.URL.String(), req.Header, string(reqBody))
-		//}
-
-		// Added some logging/"poor man's" debugging to inspect outbound SOAP requests
-		if level := os.Getenv("DEBUG"); level != "" {
-			fmt.Printf("request ->\n%s\n%#v\n%s\n", req.URL.String(), req.Header, string(reqBody))
-		}
-
-		resp, err := a.Client.Do(req)
-		if err != nil {
-			return []byte{}, err
-		}
-		defer resp.Body.Close()
-
-		var reader io.ReadCloser
-		switch resp.Header.Get("Content-Encoding") {
-		case "gzip":
-			reader, err = gzip.NewReader(resp.Body)
-			defer reader.Close()
-		default:
-			reader = resp.Body
-		}
-
-		respBody, err = ioutil.ReadAll(reader)
-		if err != nil {
-			return []byte{}, err
-		}
-		respStatusCode = resp.StatusCode
-	}
-
-	// save cache
-	if !ok && cache_ENABLED {
-		cache.Set(
-			[]string{
-				serviceUrl.String(),
-				action,
-				string(reqBody),
-			}, respBody,
-		)
-	}
-
-	defer stat.count(serviceUrl.Name, ok, cache_MEM, time.Since(startTime))
-
-	// Added some logging/"poor man's" debugging to inspect outbound SOAP requests
-	if level := os.Getenv("DEBUG"); level != "" {
-		fmt.Printf("response ->\n%s\n", string(respBody))
-	}
-
-	if a.Testing != nil {
-		a.Testing.Logf("respBody ->\n%s\n%s\n", string(respBody), fmt.Sprintf("%d", respStatusCode))
-	}
-
-	type soapRespHeader struct {
-		RequestId    string `xml:"requestId"`
-		ServiceName  string `xml:"serviceName"`
-		MethodName   string `xml:"methodName"`
-		Operations   int64  `xml:"operations"`
-		ResponseTime int64  `xml:"responseTime"`
-	}
-
-	type soapRespBody struct {
-		Response []byte `xml:",innerxml"`
-	}
-
-	soapResp := struct {
-		XMLName xml.Name       `xml:"http://schemas.xmlsoap.org/soap/envelope/ Envelope"`
-		Header  soapRespHeader `xml:"Header>RequestHeader"`
-		Body    soapRespBody   `xml:"http://schemas.xmlsoap.org/soap/envelope/ Body"`
-	}{}
-
-	err = xml.Unmarshal([]byte(respBody), &soapResp)
-	if err != nil {
-		return respBody, err
+		return nil, err
 	}
-	if respStatusCode == 400 || respStatusCode == 401 || respStatusCode == 403 || respStatusCode == 405 || respStatusCode == 500 {
-		fault := Fault{}
-		err = xml.Unmarshal(soapResp.Body.Response, &fault)
-		if err != nil {
-			return respBody, err
-		}
-
-		for i := range fault.Errors.ApiExceptionFaults {
-			switch fault.Errors.ApiExceptionFaults[i].ErrorsType {
-			case "AuthenticationError", "RateExceededError", "DatabaseError", "InternalApiError":
-				return soapResp.Body.Response, &baseError{
-					code:    fault.Errors.ApiExceptionFaults[i].Reason,
-					origErr: &fault.Errors,
-				}
-			}
-		}
-
-		if fault.Errors.ApiExceptionFaults == nil {
-			return soapResp.Body.Response, errors.New(fault.FaultString)
-		}
 
-		return soapResp.Body.Response, &fault.Errors
+	ex := &Exchange{
+		ServiceUrl:  serviceUrl,
+		Action:      action,
+		Method:      "POST",
+		Url:         a.endpointUrl(serviceUrl),
+		Header:      http.Header{},
+		RequestBody: reqBody,
 	}
-	return soapResp.Body.Response, err
+	ex.Header.Add("Accept", "text/xml")
+	ex.Header.Add("User-Agent", "gads (gzip)")
+	ex.Header.Add("Accept-Encoding", "gzip")
+	ex.Header.Add("Accept", "multipart/*")
+	ex.Header.Add("Content-Type", "text/xml;charset=UTF-8")
+	ex.Header.Add("SOAPAction", action)
+	//if a.Testing != nil {
+	//	a.Testing.Logf("request ->\n%s\n%#v\n%s\n", ex.Url, ex.Header, string(reqBody))
+	//}
+	return ex, nil
 }
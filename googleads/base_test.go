@@ -1,8 +1,15 @@
 package v201809
 
 import (
+	"context"
 	"crypto/rand"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func rand_str(str_size int) string {
@@ -33,3 +40,68 @@ func testAuthSetup(t *testing.T) Auth {
 	config.Auth.Testing = t
 	return config.Auth
 }
+
+type errClient struct {
+	calls  int
+	cancel func()
+}
+
+func (c *errClient) Do(req *http.Request) (*http.Response, error) {
+	c.calls++
+	c.cancel()
+	return nil, errors.New("connection reset")
+}
+
+func TestRequestContextCancelStopsRetry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client := &errClient{cancel: cancel}
+	auth := &Auth{Client: client}
+
+	start := time.Now()
+	_, err := auth.request(ctx, campaignServiceUrl, "get", struct{}{})
+	if err != context.Canceled {
+		t.Fatalf("got %v, expected %v", err, context.Canceled)
+	}
+	if client.calls != 1 {
+		t.Errorf("got %d calls, expected 1", client.calls)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("cancelled request should not wait for the retry delay")
+	}
+}
+
+type recordingClient struct {
+	countingClient
+	urls   []string
+	bodies []string
+}
+
+func (c *recordingClient) Do(req *http.Request) (*http.Response, error) {
+	c.urls = append(c.urls, req.URL.String())
+	if req.Body != nil {
+		body, _ := ioutil.ReadAll(req.Body)
+		c.bodies = append(c.bodies, string(body))
+	}
+	return c.countingClient.Do(req)
+}
+
+func TestEndpointOverride(t *testing.T) {
+	client := &recordingClient{countingClient: countingClient{status: 200, body: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><getResponse xmlns="https://adwords.google.com/api/adwords/cm/v201809"><rval><totalNumEntries>0</totalNumEntries></rval></getResponse></soap:Body></soap:Envelope>`}}
+	auth := &Auth{Client: client, Endpoint: "http://localhost:8080/api/adwords/"}
+
+	if _, _, err := NewCampaignService(auth).Get(Selector{}); err != nil {
+		t.Fatal(err)
+	}
+	NewReportDownloadService(auth).StreamAWQL("", "CSV")
+
+	expected := []string{
+		"http://localhost:8080/api/adwords/cm/v201809/CampaignService",
+		"http://localhost:8080/api/adwords/reportdownload/v201809",
+	}
+	if !reflect.DeepEqual(client.urls, expected) {
+		t.Errorf("got %v, expected %v", client.urls, expected)
+	}
+	if !strings.Contains(client.bodies[0], `xmlns="`+baseUrl+`"`) {
+		t.Errorf("expected the request to keep the %s namespace\n%s", baseUrl, client.bodies[0])
+	}
+}
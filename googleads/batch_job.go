@@ -1,6 +1,7 @@
 package v201809
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 	"strings"
@@ -94,10 +95,17 @@ func NewBatchJobService(auth *Auth) *BatchJobService {
 //	)
 //
 // 	https://developers.google.com/adwords/api/docs/reference/v201809/BatchJobService#get
-func (s *BatchJobService) Get(selector Selector) (batchJobPage BatchJobPage, err error) {
+func (s *BatchJobService) Get(selector Selector, opts ...CallOption) (batchJobPage BatchJobPage, err error) {
+	return s.GetWithContext(context.Background(), selector, opts...)
+}
+
+// GetWithContext is the same as Get with the addition of a context.
+func (s *BatchJobService) GetWithContext(ctx context.Context, selector Selector, opts ...CallOption) (batchJobPage BatchJobPage, err error) {
+	ctx = withCallOptions(ctx, opts)
 
 	selector.XMLName = xml.Name{baseUrl, "selector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		batchJobServiceUrl,
 		"get",
 		struct {
@@ -136,7 +144,13 @@ func (s *BatchJobService) Get(selector Selector) (batchJobPage BatchJobPage, err
 //	)
 //
 // 	https://developers.google.com/adwords/api/docs/reference/v201809/BatchJobService#mutate
-func (s *BatchJobService) Mutate(batchJobOperations BatchJobOperations) (batchJobs []BatchJob, err error) {
+func (s *BatchJobService) Mutate(batchJobOperations BatchJobOperations, opts ...CallOption) (batchJobs []BatchJob, err error) {
+	return s.MutateWithContext(context.Background(), batchJobOperations, opts...)
+}
+
+// MutateWithContext is the same as Mutate with the addition of a context.
+func (s *BatchJobService) MutateWithContext(ctx context.Context, batchJobOperations BatchJobOperations, opts ...CallOption) (batchJobs []BatchJob, err error) {
+	ctx = withCallOptions(ctx, opts)
 
 	mutation := struct {
 		XMLName xml.Name
@@ -147,19 +161,20 @@ func (s *BatchJobService) Mutate(batchJobOperations BatchJobOperations) (batchJo
 			Local: "mutate",
 		},
 		Ops: batchJobOperations.BatchJobOperations}
-	respBody, err := s.Auth.request(batchJobServiceUrl, "mutate", mutation)
+	respBody, err := s.Auth.request(ctx, batchJobServiceUrl, "mutate", mutation)
 	if err != nil {
 		return batchJobs, err
 	}
 	mutateResp := struct {
-		BatchJobs []BatchJob `xml:"rval>value"`
+		BatchJobs            []BatchJob           `xml:"rval>value"`
+		PartialFailureErrors partialFailureErrors `xml:"rval>partialFailureErrors"`
 	}{}
 	err = xml.Unmarshal([]byte(respBody), &mutateResp)
 	if err != nil {
 		return batchJobs, err
 	}
 
-	return mutateResp.BatchJobs, err
+	return mutateResp.BatchJobs, partialFailure(batchJobOperations.BatchJobOperations, mutateResp.PartialFailureErrors)
 }
 
 func (s *BatchJobService) Query() {
@@ -1,14 +1,13 @@
 package v201809
 
 import (
-	"bytes"
+	"context"
 	"encoding/xml"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/http"
-	"os"
 	"reflect"
+	"time"
 )
 
 type BatchJobHelper struct {
@@ -45,6 +44,12 @@ func NewBatchJobHelper(auth *Auth) *BatchJobHelper {
 //
 //	https://developers.google.com/adwords/api/docs/guides/batch-jobs?hl=en#upload_operations_to_the_upload_url
 func (s *BatchJobHelper) UploadBatchJobOperations(jobOperations []interface{}, url TemporaryUrl) (err error) {
+	return s.UploadBatchJobOperationsWithContext(context.Background(), jobOperations, url)
+}
+
+// UploadBatchJobOperationsWithContext is the same as UploadBatchJobOperations
+// with the addition of a context.
+func (s *BatchJobHelper) UploadBatchJobOperationsWithContext(ctx context.Context, jobOperations []interface{}, url TemporaryUrl) (err error) {
 
 	var operations []Operation
 	for _, operation := range jobOperations {
@@ -87,72 +92,49 @@ func (s *BatchJobHelper) UploadBatchJobOperations(jobOperations []interface{}, u
 
 		client := &http.Client{}
 
-		// Need to get the upload url
-		req, err := http.NewRequest("POST", url.Url, nil)
+		uploadUrl, err := s.batchJobUrl(url.Url)
 		if err != nil {
 			return err
 		}
 
-		req.Header.Set("Content-Type", "application/xml")
-		req.Header.Set("Content-Length", "0")
-		req.Header.Set("x-goog-resumable", "start")
-
-		response, err := client.Do(req)
-		if err != nil {
+		// Need to get the upload url
+		start := s.batchJobExchange("start", "POST", uploadUrl, nil)
+		start.Header.Set("Content-Type", "application/xml")
+		start.Header.Set("x-goog-resumable", "start")
+		if err := s.doBatchJobExchange(ctx, client, start); err != nil {
 			return err
 		}
-		defer response.Body.Close()
 
 		// If we got a valid upload url it will be 201
-		if response.StatusCode != http.StatusCreated {
-			respBody, err := ioutil.ReadAll(response.Body)
-
-			if err != nil {
-				return err
-			}
-			return errors.New(fmt.Sprintf("Invalid response received. %v received. Body: %v", response.StatusCode, string(respBody)))
+		if start.StatusCode != http.StatusCreated {
+			return errors.New(fmt.Sprintf("Invalid response received. %v received. Body: %v", start.StatusCode, string(start.ResponseBody)))
 		}
 
-		location := response.Header.Get("Location")
-
-		reqBody, err := xml.MarshalIndent(mutation, "  ", "  ")
-		bodyLength := len(reqBody)
-
+		location, err := s.batchJobUrl(start.ResponseHeader.Get("Location"))
 		if err != nil {
 			return err
 		}
 
-		req, err = http.NewRequest("PUT", location, bytes.NewReader(reqBody))
+		reqBody, err := xml.MarshalIndent(mutation, "  ", "  ")
+		bodyLength := len(reqBody)
 
 		if err != nil {
 			return err
 		}
 
-		// Set headers for incremental upload
-		req.Header.Set("Content-Type", "application/xml")
-		req.Header.Set("Content-Length", string(bodyLength))
-		req.Header.Set("Content-Range", fmt.Sprintf("bytes 0-%v/%v", bodyLength-1, bodyLength))
-
-		resp, err := client.Do(req)
-		if err != nil {
-			return err
-		}
-		defer resp.Body.Close()
+		upload := s.batchJobExchange("upload", "PUT", location, reqBody)
 
-		respBody, err := ioutil.ReadAll(resp.Body)
+		// Set headers for incremental upload
+		upload.Header.Set("Content-Type", "application/xml")
+		upload.Header.Set("Content-Range", fmt.Sprintf("bytes 0-%v/%v", bodyLength-1, bodyLength))
 
-		if err != nil {
+		if err := s.doBatchJobExchange(ctx, client, upload); err != nil {
 			return err
 		}
 
-		// Added some logging/"poor man's" debugging to inspect outbound SOAP requests
-		if level := os.Getenv("DEBUG"); level != "" {
-			fmt.Printf("response ->\n%s\n", string(respBody))
-		}
-
 		// resp seems to only return 200's and there is no error handling, but if we happen to get invalid status lets try to do something with it
-		if resp.StatusCode != http.StatusOK {
-			return errors.New("Non-200 response returned Body: " + string(respBody))
+		if upload.StatusCode != http.StatusOK {
+			return errors.New("Non-200 response returned Body: " + string(upload.ResponseBody))
 		}
 	}
 
@@ -167,18 +149,22 @@ func (s *BatchJobHelper) UploadBatchJobOperations(jobOperations []interface{}, u
 //
 //	https://developers.google.com/adwords/api/docs/guides/batch-jobs?hl=en#download_the_batch_job_results_and_check_for_errors
 func (s *BatchJobHelper) DownloadBatchJob(url TemporaryUrl) (mutateResults []MutateResults, err error) {
-	resp, err := http.Get(url.Url)
+	return s.DownloadBatchJobWithContext(context.Background(), url)
+}
 
+// DownloadBatchJobWithContext is the same as DownloadBatchJob with the
+// addition of a context.
+func (s *BatchJobHelper) DownloadBatchJobWithContext(ctx context.Context, url TemporaryUrl) (mutateResults []MutateResults, err error) {
+	downloadUrl, err := s.batchJobUrl(url.Url)
 	if err != nil {
 		return mutateResults, err
 	}
 
-	respBody, err := ioutil.ReadAll(resp.Body)
-
-	// Added some logging/"poor man's" debugging to inspect outbound SOAP requests
-	if level := os.Getenv("DEBUG"); level != "" {
-		fmt.Printf("response ->\n%s\n", string(respBody))
+	download := s.batchJobExchange("download", "GET", downloadUrl, nil)
+	if err := s.doBatchJobExchange(ctx, http.DefaultClient, download); err != nil {
+		return mutateResults, err
 	}
+	respBody := download.ResponseBody
 
 	soapResp := struct {
 		MutateResults []MutateResults `xml:"rval"`
@@ -191,3 +177,24 @@ func (s *BatchJobHelper) DownloadBatchJob(url TemporaryUrl) (mutateResults []Mut
 
 	return soapResp.MutateResults, err
 }
+
+func (s *BatchJobHelper) batchJobExchange(action, method, url string, body []byte) *Exchange {
+	return &Exchange{
+		ServiceUrl:  ServiceUrl{Name: "BatchJobHelper"},
+		Action:      action,
+		Method:      method,
+		Url:         url,
+		Header:      http.Header{},
+		RequestBody: body,
+	}
+}
+
+// doBatchJobExchange sends an exchange with a temporary batch job url
+// through the interceptors.  Temporary urls are signed, so the Auth client
+// is not used.
+func (s *BatchJobHelper) doBatchJobExchange(ctx context.Context, client HttpClient, ex *Exchange) error {
+	startTime := time.Now()
+	err := s.intercept(ctx, ex, send(client))
+	s.logExchange(ex, time.Since(startTime), err)
+	return err
+}
@@ -1,6 +1,7 @@
 package v201809
 
 import (
+	"context"
 	"encoding/xml"
 	//  "fmt"
 )
@@ -38,9 +39,16 @@ func budgetError() (err error) {
 }
 
 // Get returns budgets matching a given selector and the total count of matching budgets.
-func (s *BudgetService) Get(selector Selector) (budgets []Budget, totalCount int64, err error) {
+func (s *BudgetService) Get(selector Selector, opts ...CallOption) (budgets []Budget, totalCount int64, err error) {
+	return s.GetWithContext(context.Background(), selector, opts...)
+}
+
+// GetWithContext is the same as Get with the addition of a context.
+func (s *BudgetService) GetWithContext(ctx context.Context, selector Selector, opts ...CallOption) (budgets []Budget, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 	selector.XMLName = xml.Name{baseUrl, "selector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		budgetServiceUrl,
 		"get",
 		struct {
@@ -68,8 +76,43 @@ func (s *BudgetService) Get(selector Selector) (budgets []Budget, totalCount int
 	return getResp.Budgets, getResp.Size, err
 }
 
+// BudgetIterator walks the budgets of a selector, see
+// BudgetService.Iterate.
+type BudgetIterator struct {
+	*pageIterator
+}
+
+// Budget returns the current budget.
+func (it *BudgetIterator) Budget() Budget {
+	return it.page.([]Budget)[it.index]
+}
+
+// Iterate returns an iterator over all budgets matching selector, which
+// fetches them page by page, see CampaignService.Iterate.
+func (s *BudgetService) Iterate(selector Selector, opts ...CallOption) *BudgetIterator {
+	return s.IterateWithContext(context.Background(), selector, opts...)
+}
+
+// IterateWithContext is the same as Iterate with the addition of a context,
+// the iteration stops with the error of the context once it is done.
+func (s *BudgetService) IterateWithContext(ctx context.Context, selector Selector, opts ...CallOption) *BudgetIterator {
+	it := &BudgetIterator{pageIterator: newPageIterator(ctx, selector, "BudgetId")}
+	it.fetch = func(ctx context.Context, selector Selector) (interface{}, int, int64, error) {
+		budgets, totalCount, err := s.GetWithContext(ctx, selector, opts...)
+		return budgets, len(budgets), totalCount, err
+	}
+	it.id = func(i int) int64 { return it.page.([]Budget)[i].Id }
+	return it
+}
+
 // Mutate takes a budgetOperations and creates, modifies or destroys the associated budgets.
-func (s *BudgetService) Mutate(budgetOperations BudgetOperations) (budgets []Budget, err error) {
+func (s *BudgetService) Mutate(budgetOperations BudgetOperations, opts ...CallOption) (budgets []Budget, err error) {
+	return s.MutateWithContext(context.Background(), budgetOperations, opts...)
+}
+
+// MutateWithContext is the same as Mutate with the addition of a context.
+func (s *BudgetService) MutateWithContext(ctx context.Context, budgetOperations BudgetOperations, opts ...CallOption) (budgets []Budget, err error) {
+	ctx = withCallOptions(ctx, opts)
 	type budgetOperation struct {
 		Action string `xml:"operator"`
 		Budget Budget `xml:"operand"`
@@ -86,6 +129,7 @@ func (s *BudgetService) Mutate(budgetOperations BudgetOperations) (budgets []Bud
 		}
 	}
 	respBody, err := s.Auth.request(
+		ctx,
 		budgetServiceUrl,
 		"mutate",
 		struct {
@@ -103,11 +147,12 @@ func (s *BudgetService) Mutate(budgetOperations BudgetOperations) (budgets []Bud
 		return budgets, err
 	}
 	mutateResp := struct {
-		Budgets []Budget `xml:"rval>value"`
+		Budgets              []Budget             `xml:"rval>value"`
+		PartialFailureErrors partialFailureErrors `xml:"rval>partialFailureErrors"`
 	}{}
 	err = xml.Unmarshal([]byte(respBody), &mutateResp)
 	if err != nil {
 		return budgets, err
 	}
-	return mutateResp.Budgets, err
+	return mutateResp.Budgets, partialFailure(operations, mutateResp.PartialFailureErrors)
 }
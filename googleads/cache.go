@@ -1,73 +1,308 @@
 package v201809
 
 import (
+	"bytes"
+	"container/list"
 	sha256 "crypto/sha256"
 	"encoding/hex"
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
-// cache
-// todo: make this concurrent
+// Cache stores responses to read requests, see Auth.Cache.  Implementations
+// must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored for key unless it expired.
+	Get(key string) ([]byte, bool)
 
-type callCache struct {
-	Items map[string][]byte
+	// Set stores value for key, expiring after ttl or never if ttl <= 0.
+	Set(key string, value []byte, ttl time.Duration)
 }
 
-var (
-	cache_DIR     = ""
-	cache_ENABLED = false
-	cache_MEM     = false
-	cache         *callCache
-)
+// isReadAction reports whether a SOAP action only reads data, like get,
+// query or getAdGroupBidLandscape.  Responses to other actions are never
+// cached.
+func isReadAction(action string) bool {
+	return strings.HasPrefix(action, "get") || strings.HasPrefix(action, "query")
+}
+
+// cacheTTL returns the cache the response to ex is kept in and for how
+// long, or false if it must not be cached.  Without a Cache of its own, Auth
+// uses the cache of InitCache while it is enabled, which keeps the responses
+// to all read actions.
+func (a *Auth) cacheTTL(ex *Exchange) (Cache, time.Duration, bool) {
+	if !isReadAction(ex.Action) {
+		return nil, 0, false
+	}
+	if a.Cache == nil {
+		if legacyCache.active() {
+			return legacyCache, 0, true
+		}
+		return nil, 0, false
+	}
+	for _, k := range []string{ex.ServiceUrl.Name + "." + ex.Action, ex.ServiceUrl.Name, ""} {
+		if ttl, ok := a.CacheTTL[k]; ok {
+			return a.Cache, ttl, ttl > 0
+		}
+	}
+	return nil, 0, false
+}
+
+// cacheKey identifies the response to ex.  The request body holds the SOAP
+// header, so responses for other accounts or developer tokens get other
+// keys.
+func cacheKey(ex *Exchange) string {
+	hashBuffer := sha256.Sum256([]byte(strings.Join([]string{
+		ex.Url,
+		ex.Action,
+		string(ex.RequestBody),
+	}, "\n")))
+	return hex.EncodeToString(hashBuffer[:])
+}
+
+// LRUCache is an in-memory Cache holding at most a given number of
+// responses, evicting the least recently used first.
+type LRUCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    *list.List               // most recently used first
+	items      map[string]*list.Element // of *lruEntry
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time // zero if it never expires
+}
 
-func (c *callCache) Set(k []string, v []byte) {
-	hashBuffer := sha256.Sum256([]byte(strings.Join(k, "-")))
-	key := hex.EncodeToString(hashBuffer[:])
-	c.Items[key] = v
+// NewLRUCache returns an LRUCache of at most maxEntries responses, or of
+// unlimited size if maxEntries <= 0.
+func NewLRUCache(maxEntries int) *LRUCache {
+	return &LRUCache{
+		maxEntries: maxEntries,
+		entries:    list.New(),
+		items:      map[string]*list.Element{},
+	}
 }
 
-func (c *callCache) Get(k []string) ([]byte, bool) {
-	hashBuffer := sha256.Sum256([]byte(strings.Join(k, "-")))
-	key := hex.EncodeToString(hashBuffer[:])
-	cache_MEM = false
-	if v, ok := c.Items[key]; ok {
-		cache_MEM = true
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*lruEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		c.entries.Remove(el)
+		delete(c.items, key)
+		return nil, false
+	}
+	c.entries.MoveToFront(el)
+	return entry.value, true
+}
+
+func (c *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	entry := &lruEntry{key: key, value: value}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		el.Value = entry
+		c.entries.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.entries.PushFront(entry)
+	if c.maxEntries > 0 && c.entries.Len() > c.maxEntries {
+		oldest := c.entries.Back()
+		c.entries.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Len returns the number of responses held, including expired ones not
+// evicted yet.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.Len()
+}
+
+// DiskCache is a Cache storing every response in a file of a directory, it
+// survives restarts and may be shared by processes.  A file holds the
+// expiry time in unix nanoseconds, 0 for never, followed by a newline and
+// the response.
+type DiskCache struct {
+	Dir string
+}
+
+// NewDiskCache returns a DiskCache storing responses in dir, which is
+// created if need be.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &DiskCache{Dir: dir}, nil
+}
+
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	d, err := ioutil.ReadFile(filepath.Join(c.Dir, key))
+	if err != nil {
+		return nil, false
+	}
+	i := bytes.IndexByte(d, '\n')
+	if i < 0 {
+		return nil, false
+	}
+	expires, err := strconv.ParseInt(string(d[:i]), 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	if expires != 0 && time.Now().UnixNano() > expires {
+		os.Remove(filepath.Join(c.Dir, key))
+		return nil, false
+	}
+	return d[i+1:], true
+}
+
+// Set writes the response to a temporary file first, renaming it is atomic
+// so concurrent readers never see a partial response.
+func (c *DiskCache) Set(key string, value []byte, ttl time.Duration) {
+	c.write(key, value, ttl)
+}
+
+func (c *DiskCache) write(key string, value []byte, ttl time.Duration) error {
+	var expires int64
+	if ttl > 0 {
+		expires = time.Now().Add(ttl).UnixNano()
+	}
+	f, err := ioutil.TempFile(c.Dir, key+".tmp")
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(strconv.FormatInt(expires, 10) + "\n")
+	if err == nil {
+		_, err = f.Write(value)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), filepath.Join(c.Dir, key))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+// legacyCache backs the deprecated package level cache functions.
+var legacyCache = &sharedCache{}
+
+// sharedCache keeps responses in memory until SaveCache writes them to a
+// DiskCache, which is read when a response is not in memory.
+type sharedCache struct {
+	mu      sync.Mutex
+	enabled bool
+	memory  map[string][]byte
+	disk    *DiskCache
+}
+
+func (c *sharedCache) active() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.enabled && c.memory != nil
+}
+
+func (c *sharedCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	v, ok := c.memory[key]
+	disk := c.disk
+	c.mu.Unlock()
+	if ok || disk == nil {
 		return v, ok
-	} else {
-		d, err := ioutil.ReadFile(cache_DIR + key)
-		if err != nil {
-			return []byte{}, false
-		}
-		return d, true
+	}
+	return disk.Get(key)
+}
+
+func (c *sharedCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.memory != nil {
+		c.memory[key] = value
 	}
 }
 
+// InitCache enables caching the responses to the read actions of every
+// Auth without a Cache of its own, in memory until SaveCache writes them to
+// files of dir.
+//
+// Deprecated: set Auth.Cache, e.g. to a DiskCache, and Auth.CacheTTL
+// instead.  Unlike in earlier releases mutations are not cached.
 func InitCache(dir string) {
-	cache_ENABLED = true
-	cache_DIR = dir
-	cache = &callCache{
-		Items: map[string][]byte{},
+	legacyCache.mu.Lock()
+	defer legacyCache.mu.Unlock()
+	legacyCache.enabled = true
+	legacyCache.memory = map[string][]byte{}
+	legacyCache.disk = nil
+	if dir != "" {
+		legacyCache.disk = &DiskCache{Dir: dir}
 	}
 }
 
+// ResumeCache enables the cache of InitCache again after PauseCache.
+//
+// Deprecated: set Auth.Cache instead.
 func ResumeCache() {
-	cache_ENABLED = true
+	legacyCache.mu.Lock()
+	defer legacyCache.mu.Unlock()
+	legacyCache.enabled = true
 }
 
+// PauseCache disables the cache of InitCache until ResumeCache.
+//
+// Deprecated: set Auth.Cache instead, nil disabling it.
 func PauseCache() {
-	cache_ENABLED = false
+	legacyCache.mu.Lock()
+	defer legacyCache.mu.Unlock()
+	legacyCache.enabled = false
 }
 
-// call this method when you dont need memory cache
+// SaveCache writes the responses held in memory by the cache of InitCache
+// to its directory and frees the memory.
+//
+// Deprecated: use a DiskCache as Auth.Cache instead.
 func SaveCache() error {
-	for k, v := range cache.Items {
-		err := ioutil.WriteFile(cache_DIR+k, []byte(v), 0777)
-		if err != nil {
+	legacyCache.mu.Lock()
+	defer legacyCache.mu.Unlock()
+	if legacyCache.disk == nil {
+		legacyCache.memory = map[string][]byte{}
+		return nil
+	}
+	if err := os.MkdirAll(legacyCache.disk.Dir, 0755); err != nil {
+		return err
+	}
+	for k, v := range legacyCache.memory {
+		if err := legacyCache.disk.write(k, v, 0); err != nil {
 			return err
 		}
+		delete(legacyCache.memory, k)
 	}
-	// clear for memory save
-	cache.Items = map[string][]byte{}
 	return nil
 }
+
+// SetCacheToken used to tell apart the cached responses of different
+// credentials.
+//
+// Deprecated: cache keys include the SOAP header of the request, so the
+// responses of other accounts and developer tokens are told apart without
+// it.  SetCacheToken does nothing.
+func SetCacheToken(t string) {}
@@ -0,0 +1,205 @@
+package v201809
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCacheReadActionsOnly(t *testing.T) {
+	client := &countingClient{status: 200, body: soapGetResponse("")}
+	auth := &Auth{
+		Client:   client,
+		Cache:    NewLRUCache(10),
+		CacheTTL: map[string]time.Duration{"": time.Minute},
+	}
+	campaigns := NewCampaignService(auth)
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := campaigns.Get(Selector{Fields: []string{"Id"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if client.calls != 1 {
+		t.Errorf("expected the second get to be cached, got %d calls", client.calls)
+	}
+
+	// other accounts get other keys
+	if _, _, err := campaigns.Get(Selector{Fields: []string{"Id"}}, WithCustomerId("222-222-2222")); err != nil {
+		t.Fatal(err)
+	}
+	if client.calls != 2 {
+		t.Errorf("expected a get for another account to be sent, got %d calls", client.calls)
+	}
+
+	client.body = soapMutateResponse(`<value><id>1</id><name>label</name></value>`)
+	labels := NewLabelService(auth)
+	for i := 0; i < 2; i++ {
+		if _, err := labels.Mutate(LabelOperations{"ADD": {Label{Name: "label"}}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if client.calls != 4 {
+		t.Errorf("expected mutations never to be cached, got %d calls", client.calls)
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	client := &countingClient{status: 200, body: soapGetResponse("")}
+	auth := &Auth{
+		Client: client,
+		Cache:  NewLRUCache(10),
+		CacheTTL: map[string]time.Duration{
+			"CampaignService.get": time.Minute,
+			"AdGroupService":      0,
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		NewCampaignService(auth).Get(Selector{})
+		NewAdGroupService(auth).Get(Selector{})
+		NewBudgetService(auth).Get(Selector{})
+	}
+	if client.calls != 5 {
+		t.Errorf("expected only campaigns to be cached, got %d calls", client.calls)
+	}
+}
+
+func TestCacheErrorsNotCached(t *testing.T) {
+	client := &countingClient{status: 500, body: testFault("EntityNotFound", "INVALID_ID", "")}
+	auth := &Auth{
+		Client:      client,
+		RetryPolicy: NoRetry,
+		Cache:       NewLRUCache(10),
+		CacheTTL:    map[string]time.Duration{"": time.Minute},
+	}
+	for i := 0; i < 2; i++ {
+		if _, _, err := NewCampaignService(auth).Get(Selector{}); err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+	if client.calls != 2 {
+		t.Errorf("expected errors not to be cached, got %d calls", client.calls)
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	c := NewLRUCache(2)
+	c.Set("a", []byte("a"), 0)
+	c.Set("b", []byte("b"), 0)
+	c.Get("a")
+	c.Set("c", []byte("c"), 0)
+	if _, ok := c.Get("b"); ok {
+		t.Error("expected the least recently used entry to be evicted")
+	}
+	if v, ok := c.Get("a"); !ok || string(v) != "a" {
+		t.Errorf("got %q", v)
+	}
+
+	c.Set("d", []byte("d"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := c.Get("d"); ok {
+		t.Error("expected the entry to expire")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := fmt.Sprint(i, j%5)
+				c.Set(key, []byte(key), time.Minute)
+				c.Get(key)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if c.Len() != 2 {
+		t.Errorf("got %d entries", c.Len())
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gads-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Set("key", []byte("response\nbody"), time.Minute)
+	if v, ok := c.Get("key"); !ok || string(v) != "response\nbody" {
+		t.Errorf("got %q", v)
+	}
+
+	// another process, or a restart, sees the same responses
+	if v, ok := (&DiskCache{Dir: dir}).Get("key"); !ok || string(v) != "response\nbody" {
+		t.Errorf("got %q", v)
+	}
+
+	c.Set("expiring", []byte("response"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := c.Get("expiring"); ok {
+		t.Error("expected the entry to expire")
+	}
+	if _, ok := c.Get("missing"); ok {
+		t.Error("expected a miss")
+	}
+}
+
+func TestCacheDeprecatedFunctions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gads")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer PauseCache()
+
+	client := &countingClient{status: 200, body: soapGetResponse("")}
+	campaigns := NewCampaignService(&Auth{Client: client})
+	get := func() {
+		t.Helper()
+		if _, _, err := campaigns.Get(Selector{Fields: []string{"Id"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	InitCache(dir)
+	get()
+	get()
+	if client.calls != 1 {
+		t.Errorf("expected the second get to be cached, got %d calls", client.calls)
+	}
+
+	PauseCache()
+	get()
+	if client.calls != 2 {
+		t.Errorf("expected no cache while paused, got %d calls", client.calls)
+	}
+	ResumeCache()
+
+	// saved responses are read back from dir
+	if err := SaveCache(); err != nil {
+		t.Fatal(err)
+	}
+	if files, _ := ioutil.ReadDir(dir); len(files) != 1 {
+		t.Errorf("expected a saved response, got %d files", len(files))
+	}
+	get()
+	if client.calls != 2 {
+		t.Errorf("expected the saved response to be used, got %d calls", client.calls)
+	}
+
+	// an Auth with a Cache of its own does not use it
+	NewCampaignService(&Auth{Client: client, Cache: NewLRUCache(1)}).Get(Selector{Fields: []string{"Id"}})
+	if client.calls != 3 {
+		t.Errorf("expected Auth.Cache to take precedence, got %d calls", client.calls)
+	}
+}
@@ -1,6 +1,7 @@
 package v201809
 
 import (
+	"context"
 	"encoding/xml"
 )
 
@@ -198,13 +199,20 @@ type CampaignLabelOperations map[string][]CampaignLabel
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201409/CampaignService#get
 //
-func (s *CampaignService) Get(selector Selector) (campaigns []Campaign, totalCount int64, err error) {
+func (s *CampaignService) Get(selector Selector, opts ...CallOption) (campaigns []Campaign, totalCount int64, err error) {
+	return s.GetWithContext(context.Background(), selector, opts...)
+}
+
+// GetWithContext is the same as Get with the addition of a context.
+func (s *CampaignService) GetWithContext(ctx context.Context, selector Selector, opts ...CallOption) (campaigns []Campaign, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 	// The default namespace, "", will break in 1.5 with the addition of
 	// custom namespace support.  Hence, we have to ensure that the baseUrl is
 	// set again as the proper namespace for the service/serviceSelector element
 	selector.XMLName = xml.Name{baseUrl, "serviceSelector"}
 
 	respBody, err := s.Auth.request(
+		ctx,
 		campaignServiceUrl,
 		"get",
 		struct {
@@ -232,6 +240,106 @@ func (s *CampaignService) Get(selector Selector) (campaigns []Campaign, totalCou
 	return getResp.Campaigns, getResp.Size, err
 }
 
+// GetEach is the same as Get but decodes the campaigns one at a time while
+// the response is read and passes each of them to fn instead of returning
+// them.  Decoding stops at the first error returned by fn.
+//
+// Example
+//
+//   totalCount, err := campaignService.GetEach(selector, func(c gads.Campaign) error {
+//     ...
+//     return nil
+//   })
+//
+func (s *CampaignService) GetEach(selector Selector, fn func(Campaign) error, opts ...CallOption) (totalCount int64, err error) {
+	return s.GetEachWithContext(context.Background(), selector, fn, opts...)
+}
+
+// GetEachWithContext is the same as GetEach with the addition of a context.
+func (s *CampaignService) GetEachWithContext(ctx context.Context, selector Selector, fn func(Campaign) error, opts ...CallOption) (totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
+	selector.XMLName = xml.Name{Space: baseUrl, Local: "serviceSelector"}
+	return s.Auth.stream(
+		ctx,
+		campaignServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Sel     Selector
+		}{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "get",
+			},
+			Sel: selector,
+		},
+		campaignEntries(fn),
+	)
+}
+
+// CampaignIterator walks the campaigns of a selector or query, see
+// CampaignService.Iterate.
+type CampaignIterator struct {
+	*pageIterator
+}
+
+// Campaign returns the current campaign.
+func (it *CampaignIterator) Campaign() Campaign {
+	return it.page.([]Campaign)[it.index]
+}
+
+// Iterate returns an iterator over all campaigns matching selector, which
+// fetches them page by page as the iteration proceeds.  Paging.Limit sets
+// the page size, DefaultPageSize by default.  Past the 100,000 entries the
+// API pages through, the selector is split on ranges of ids unless it has
+// an Ordering.  Setting Workers on the iterator fetches the pages ahead
+// concurrently, Close cancels them when leaving the loop early.
+//
+// Example
+//
+//   it := campaignService.Iterate(selector)
+//   defer it.Close()
+//   for it.Next() {
+//     campaign := it.Campaign()
+//     ...
+//   }
+//   if err := it.Err(); err != nil {
+//     ...
+//   }
+//
+func (s *CampaignService) Iterate(selector Selector, opts ...CallOption) *CampaignIterator {
+	return s.IterateWithContext(context.Background(), selector, opts...)
+}
+
+// IterateWithContext is the same as Iterate with the addition of a context,
+// the iteration stops with the error of the context once it is done.
+func (s *CampaignService) IterateWithContext(ctx context.Context, selector Selector, opts ...CallOption) *CampaignIterator {
+	it := &CampaignIterator{pageIterator: newPageIterator(ctx, selector, "Id")}
+	it.fetch = func(ctx context.Context, selector Selector) (interface{}, int, int64, error) {
+		campaigns, totalCount, err := s.GetWithContext(ctx, selector, opts...)
+		return campaigns, len(campaigns), totalCount, err
+	}
+	it.id = func(i int) int64 { return it.page.([]Campaign)[i].Id }
+	return it
+}
+
+// IterateQuery returns an iterator over the campaigns of an AWQL query without
+// LIMIT clause, see Iterate.  Queries are not split on ids, the iteration
+// stops with ErrPagingLimit past 100,000 entries.
+func (s *CampaignService) IterateQuery(query string, opts ...CallOption) *CampaignIterator {
+	return s.IterateQueryWithContext(context.Background(), query, opts...)
+}
+
+// IterateQueryWithContext is the same as IterateQuery with the addition of a context.
+func (s *CampaignService) IterateQueryWithContext(ctx context.Context, query string, opts ...CallOption) *CampaignIterator {
+	it := &CampaignIterator{pageIterator: newPageIterator(ctx, Selector{}, "")}
+	it.fetch = queryPage(query, func(ctx context.Context, query string) (interface{}, int, int64, error) {
+		campaigns, totalCount, err := s.QueryWithContext(ctx, query, opts...)
+		return campaigns, len(campaigns), totalCount, err
+	})
+	return it
+}
+
 // Mutate allows you to add and modify campaigns, returning the
 // campaigns.  Note that the "REMOVE" operator is not supported.
 // To remove a campaign set its Status to "REMOVED".
@@ -272,7 +380,13 @@ type CampaignOperation struct {
 	Campaign Campaign `xml:"operand"`
 }
 
-func (s *CampaignService) MutateOperations(ops []CampaignOperation) (campaigns []Campaign, err error) {
+func (s *CampaignService) MutateOperations(ops []CampaignOperation, opts ...CallOption) (campaigns []Campaign, err error) {
+	return s.MutateOperationsWithContext(context.Background(), ops, opts...)
+}
+
+// MutateOperationsWithContext is the same as MutateOperations with the addition of a context.
+func (s *CampaignService) MutateOperationsWithContext(ctx context.Context, ops []CampaignOperation, opts ...CallOption) (campaigns []Campaign, err error) {
+	ctx = withCallOptions(ctx, opts)
 	mutation := struct {
 		XMLName xml.Name
 		Ops     []CampaignOperation `xml:"operations"`
@@ -282,22 +396,39 @@ func (s *CampaignService) MutateOperations(ops []CampaignOperation) (campaigns [
 			Local: "mutate",
 		},
 		Ops: ops}
-	respBody, err := s.Auth.request(campaignServiceUrl, "mutate", mutation)
+	respBody, err := s.Auth.request(ctx, campaignServiceUrl, "mutate", mutation)
 	if err != nil {
 		return campaigns, err
 	}
 	mutateResp := struct {
-		Campaigns []Campaign `xml:"rval>value"`
+		Campaigns            []Campaign           `xml:"rval>value"`
+		PartialFailureErrors partialFailureErrors `xml:"rval>partialFailureErrors"`
 	}{}
 	err = xml.Unmarshal([]byte(respBody), &mutateResp)
 	if err != nil {
 		return campaigns, err
 	}
 
+	err = partialFailure(ops, mutateResp.PartialFailureErrors)
+	if pfe, ok := err.(*PartialFailureError); ok {
+		for _, failure := range pfe.Failures {
+			if failure.Index < len(mutateResp.Campaigns) {
+				campaign := ops[failure.Index].Campaign
+				campaign.Errors = failure.errs()
+				mutateResp.Campaigns[failure.Index] = campaign
+			}
+		}
+	}
 	return mutateResp.Campaigns, err
 }
 
-func (s *CampaignService) Mutate(campaignOperations CampaignOperations) (campaigns []Campaign, err error) {
+func (s *CampaignService) Mutate(campaignOperations CampaignOperations, opts ...CallOption) (campaigns []Campaign, err error) {
+	return s.MutateWithContext(context.Background(), campaignOperations, opts...)
+}
+
+// MutateWithContext is the same as Mutate with the addition of a context.
+func (s *CampaignService) MutateWithContext(ctx context.Context, campaignOperations CampaignOperations, opts ...CallOption) (campaigns []Campaign, err error) {
+	ctx = withCallOptions(ctx, opts)
 	operations := []CampaignOperation{}
 	for action, campaigns := range campaignOperations {
 		for _, campaign := range campaigns {
@@ -309,7 +440,7 @@ func (s *CampaignService) Mutate(campaignOperations CampaignOperations) (campaig
 			)
 		}
 	}
-	return s.MutateOperations(operations)
+	return s.MutateOperationsWithContext(ctx, operations)
 }
 
 // Mutate allows you to add and removes labels from campaigns.
@@ -331,7 +462,13 @@ func (s *CampaignService) Mutate(campaignOperations CampaignOperations) (campaig
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201409/CampaignService#mutateLabel
 //
-func (s *CampaignService) MutateLabel(campaignLabelOperations CampaignLabelOperations) (campaignLabels []CampaignLabel, err error) {
+func (s *CampaignService) MutateLabel(campaignLabelOperations CampaignLabelOperations, opts ...CallOption) (campaignLabels []CampaignLabel, err error) {
+	return s.MutateLabelWithContext(context.Background(), campaignLabelOperations, opts...)
+}
+
+// MutateLabelWithContext is the same as MutateLabel with the addition of a context.
+func (s *CampaignService) MutateLabelWithContext(ctx context.Context, campaignLabelOperations CampaignLabelOperations, opts ...CallOption) (campaignLabels []CampaignLabel, err error) {
+	ctx = withCallOptions(ctx, opts)
 	type campaignLabelOperation struct {
 		Action        string        `xml:"operator"`
 		CampaignLabel CampaignLabel `xml:"operand"`
@@ -356,19 +493,20 @@ func (s *CampaignService) MutateLabel(campaignLabelOperations CampaignLabelOpera
 			Local: "mutateLabel",
 		},
 		Ops: operations}
-	respBody, err := s.Auth.request(campaignServiceUrl, "mutateLabel", mutation)
+	respBody, err := s.Auth.request(ctx, campaignServiceUrl, "mutateLabel", mutation)
 	if err != nil {
 		return campaignLabels, err
 	}
 	mutateResp := struct {
-		CampaignLabels []CampaignLabel `xml:"rval>value"`
+		CampaignLabels       []CampaignLabel      `xml:"rval>value"`
+		PartialFailureErrors partialFailureErrors `xml:"rval>partialFailureErrors"`
 	}{}
 	err = xml.Unmarshal([]byte(respBody), &mutateResp)
 	if err != nil {
 		return campaignLabels, err
 	}
 
-	return mutateResp.CampaignLabels, err
+	return mutateResp.CampaignLabels, partialFailure(operations, mutateResp.PartialFailureErrors)
 }
 
 // Query is not yet implemented
@@ -377,9 +515,16 @@ func (s *CampaignService) MutateLabel(campaignLabelOperations CampaignLabelOpera
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201409/CampaignService#query
 //
-func (s *CampaignService) Query(query string) (campaigns []Campaign, totalCount int64, err error) {
+func (s *CampaignService) Query(query string, opts ...CallOption) (campaigns []Campaign, totalCount int64, err error) {
+	return s.QueryWithContext(context.Background(), query, opts...)
+}
+
+// QueryWithContext is the same as Query with the addition of a context.
+func (s *CampaignService) QueryWithContext(ctx context.Context, query string, opts ...CallOption) (campaigns []Campaign, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 
 	respBody, err := s.Auth.request(
+		ctx,
 		campaignServiceUrl,
 		"query",
 		AWQLQuery{
@@ -406,3 +551,38 @@ func (s *CampaignService) Query(query string) (campaigns []Campaign, totalCount
 	}
 	return getResp.Campaigns, getResp.Size, err
 }
+
+// QueryEach is the same as Query but passes the campaigns to fn one at a time
+// as they are decoded, see GetEach.
+func (s *CampaignService) QueryEach(query string, fn func(Campaign) error, opts ...CallOption) (totalCount int64, err error) {
+	return s.QueryEachWithContext(context.Background(), query, fn, opts...)
+}
+
+// QueryEachWithContext is the same as QueryEach with the addition of a context.
+func (s *CampaignService) QueryEachWithContext(ctx context.Context, query string, fn func(Campaign) error, opts ...CallOption) (totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
+	return s.Auth.stream(
+		ctx,
+		campaignServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+		campaignEntries(fn),
+	)
+}
+
+// campaignEntries decodes streamed campaigns for fn.
+func campaignEntries(fn func(Campaign) error) entryFunc {
+	return func(dec *xml.Decoder, start *xml.StartElement) error {
+		var campaign Campaign
+		if err := dec.DecodeElement(&campaign, start); err != nil {
+			return err
+		}
+		return fn(campaign)
+	}
+}
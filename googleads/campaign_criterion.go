@@ -1,6 +1,7 @@
 package v201809
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 )
@@ -150,7 +151,13 @@ func NewNegativeCampaignCriterion(campaignId int64, bidModifier float64, criteri
 }
 */
 
-func (s *CampaignCriterionService) Get(selector Selector) (campaignCriterions CampaignCriterions, totalCount int64, err error) {
+func (s *CampaignCriterionService) Get(selector Selector, opts ...CallOption) (campaignCriterions CampaignCriterions, totalCount int64, err error) {
+	return s.GetWithContext(context.Background(), selector, opts...)
+}
+
+// GetWithContext is the same as Get with the addition of a context.
+func (s *CampaignCriterionService) GetWithContext(ctx context.Context, selector Selector, opts ...CallOption) (campaignCriterions CampaignCriterions, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 	selector.XMLName = xml.Name{baseUrl, "serviceSelector"}
 	getResp := struct {
 		XMLName            xml.Name
@@ -159,6 +166,7 @@ func (s *CampaignCriterionService) Get(selector Selector) (campaignCriterions Ca
 	}{}
 
 	err = s.Auth.do(
+		ctx,
 		campaignCriterionServiceUrl,
 		"get",
 		struct {
@@ -179,12 +187,101 @@ func (s *CampaignCriterionService) Get(selector Selector) (campaignCriterions Ca
 	return getResp.CampaignCriterions, getResp.Size, err
 }
 
+// GetEach is the same as Get but decodes the criteria one at a time while
+// the response is read and passes each of them to fn instead of returning
+// them.  Decoding stops at the first error returned by fn.
+//
+// Example
+//
+//   totalCount, err := campaignCriterionService.GetEach(selector, func(campaignCriterion interface{}) error {
+//     ...
+//     return nil
+//   })
+//
+func (s *CampaignCriterionService) GetEach(selector Selector, fn func(interface{}) error, opts ...CallOption) (totalCount int64, err error) {
+	return s.GetEachWithContext(context.Background(), selector, fn, opts...)
+}
+
+// GetEachWithContext is the same as GetEach with the addition of a context.
+func (s *CampaignCriterionService) GetEachWithContext(ctx context.Context, selector Selector, fn func(interface{}) error, opts ...CallOption) (totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
+	selector.XMLName = xml.Name{Space: baseUrl, Local: "serviceSelector"}
+	return s.Auth.stream(
+		ctx,
+		campaignCriterionServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Sel     Selector
+		}{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "get",
+			},
+			Sel: selector,
+		},
+		campaignCriterionEntries(fn),
+	)
+}
+
+// CampaignCriterionIterator walks the campaign criterions of a selector or
+// query, see CampaignCriterionService.Iterate.
+type CampaignCriterionIterator struct {
+	*pageIterator
+}
+
+// CampaignCriterion returns the current campaign criterion.
+func (it *CampaignCriterionIterator) CampaignCriterion() interface{} {
+	return it.page.(CampaignCriterions)[it.index]
+}
+
+// Iterate returns an iterator over all campaign criterions matching selector, which
+// fetches them page by page, see CampaignService.Iterate.
+func (s *CampaignCriterionService) Iterate(selector Selector, opts ...CallOption) *CampaignCriterionIterator {
+	return s.IterateWithContext(context.Background(), selector, opts...)
+}
+
+// IterateWithContext is the same as Iterate with the addition of a context,
+// the iteration stops with the error of the context once it is done.
+func (s *CampaignCriterionService) IterateWithContext(ctx context.Context, selector Selector, opts ...CallOption) *CampaignCriterionIterator {
+	it := &CampaignCriterionIterator{pageIterator: newPageIterator(ctx, selector, "Id", "CampaignId")}
+	it.fetch = func(ctx context.Context, selector Selector) (interface{}, int, int64, error) {
+		campaignCriterions, totalCount, err := s.GetWithContext(ctx, selector, opts...)
+		return campaignCriterions, len(campaignCriterions), totalCount, err
+	}
+	it.id = func(i int) int64 { return entityId(it.page.(CampaignCriterions)[i], "Criterion", "Id") }
+	return it
+}
+
+// IterateQuery returns an iterator over the campaign criterions of an AWQL query without
+// LIMIT clause, see Iterate.  Queries are not split on ids, the iteration
+// stops with ErrPagingLimit past 100,000 entries.
+func (s *CampaignCriterionService) IterateQuery(query string, opts ...CallOption) *CampaignCriterionIterator {
+	return s.IterateQueryWithContext(context.Background(), query, opts...)
+}
+
+// IterateQueryWithContext is the same as IterateQuery with the addition of a context.
+func (s *CampaignCriterionService) IterateQueryWithContext(ctx context.Context, query string, opts ...CallOption) *CampaignCriterionIterator {
+	it := &CampaignCriterionIterator{pageIterator: newPageIterator(ctx, Selector{}, "")}
+	it.fetch = queryPage(query, func(ctx context.Context, query string) (interface{}, int, int64, error) {
+		campaignCriterions, totalCount, err := s.QueryWithContext(ctx, query, opts...)
+		return campaignCriterions, len(campaignCriterions), totalCount, err
+	})
+	return it
+}
+
 type CampaignCriterionOperation struct {
 	Action            string      `xml:"operator"`
 	CampaignCriterion interface{} `xml:"operand"`
 }
 
-func (s *CampaignCriterionService) MutateOperations(operations []CampaignCriterionOperation) (CampaignCriterions, error) {
+func (s *CampaignCriterionService) MutateOperations(operations []CampaignCriterionOperation, opts ...CallOption) (CampaignCriterions, error) {
+	return s.MutateOperationsWithContext(context.Background(), operations, opts...)
+}
+
+// MutateOperationsWithContext is the same as MutateOperations with the addition of a context.
+func (s *CampaignCriterionService) MutateOperationsWithContext(ctx context.Context, operations []CampaignCriterionOperation, opts ...CallOption) (CampaignCriterions, error) {
+	ctx = withCallOptions(ctx, opts)
 	mutation := struct {
 		XMLName xml.Name
 		Ops     []CampaignCriterionOperation `xml:"operations"`
@@ -197,46 +294,41 @@ func (s *CampaignCriterionService) MutateOperations(operations []CampaignCriteri
 	}
 
 	mutateResp := struct {
-		XMLName            xml.Name
-		CampaignCriterions CampaignCriterions `xml:"rval>value"`
+		XMLName              xml.Name
+		CampaignCriterions   CampaignCriterions   `xml:"rval>value"`
+		PartialFailureErrors partialFailureErrors `xml:"rval>partialFailureErrors"`
 	}{}
-	err := s.Auth.do(campaignCriterionServiceUrl, "mutate", mutation, &mutateResp)
+	err := s.Auth.do(ctx, campaignCriterionServiceUrl, "mutate", mutation, &mutateResp)
 	if err != nil {
-		/*
-			    switch t := err.(type) {
-			    case *ErrorsType:
-				    for action, campaignCriterions := range campaignCriterionOperations {
-					    for _, campaignCriterion := range campaignCriterions {
-			          campaignCriterions = append(campaignCriterions,campaignCriterion)
-			        }
-			      }
-			      for _, aef := range t.ApiExceptionFaults {
-			        for _,e := range aef.Errors {
-			          switch et := e.(type) {
-			          case CriterionError:
-			            offset, err := strconv.ParseInt(strings.Trim(et.FieldPath,"abcdefghijklmnop.]["),10,64)
-			            if err != nil {
-			              return CampaignCriterions{}, err
-			            }
-			            cc := campaignCriterions[offset]
-			            switch c := cc.(type) {
-			            case CampaignCriterion:
-			              CampaignCriterion(campaignCriterions[offset]).Errors = append(campaignCriterions[offset].(CampaignCriterion).Errors,fmt.Errorf(et.Reason))
-			            case NegativeCampaignCriterion:
-			              NegativeCampaignCriterion(campaignCriterions[offset]).Errors = append(NegativeCampaignCriterion(campaignCriterions[offset].Errors),fmt.Errorf(et.Reason))
-			            }
-			          }
-			        }
-			      }
-			    default:
-		*/
 		return nil, err
-		//}
+	}
+
+	err = partialFailure(operations, mutateResp.PartialFailureErrors)
+	if pfe, ok := err.(*PartialFailureError); ok {
+		for _, failure := range pfe.Failures {
+			if failure.Index >= len(mutateResp.CampaignCriterions) {
+				continue
+			}
+			switch cc := failure.Operand.(type) {
+			case CampaignCriterion:
+				cc.Errors = failure.errs()
+				mutateResp.CampaignCriterions[failure.Index] = cc
+			case NegativeCampaignCriterion:
+				cc.Errors = failure.errs()
+				mutateResp.CampaignCriterions[failure.Index] = cc
+			}
+		}
 	}
 	return mutateResp.CampaignCriterions, err
 }
 
-func (s *CampaignCriterionService) Mutate(campaignCriterionOperations CampaignCriterionOperations) (campaignCriterions CampaignCriterions, err error) {
+func (s *CampaignCriterionService) Mutate(campaignCriterionOperations CampaignCriterionOperations, opts ...CallOption) (campaignCriterions CampaignCriterions, err error) {
+	return s.MutateWithContext(context.Background(), campaignCriterionOperations, opts...)
+}
+
+// MutateWithContext is the same as Mutate with the addition of a context.
+func (s *CampaignCriterionService) MutateWithContext(ctx context.Context, campaignCriterionOperations CampaignCriterionOperations, opts ...CallOption) (campaignCriterions CampaignCriterions, err error) {
+	ctx = withCallOptions(ctx, opts)
 	operations := []CampaignCriterionOperation{}
 	for action, campaignCriterions := range campaignCriterionOperations {
 		for _, campaignCriterion := range campaignCriterions {
@@ -249,11 +341,18 @@ func (s *CampaignCriterionService) Mutate(campaignCriterionOperations CampaignCr
 		}
 	}
 
-	return s.MutateOperations(operations)
+	return s.MutateOperationsWithContext(ctx, operations)
 }
 
-func (s *CampaignCriterionService) Query(query string) (campaignCriterions CampaignCriterions, totalCount int64, err error) {
+func (s *CampaignCriterionService) Query(query string, opts ...CallOption) (campaignCriterions CampaignCriterions, totalCount int64, err error) {
+	return s.QueryWithContext(context.Background(), query, opts...)
+}
+
+// QueryWithContext is the same as Query with the addition of a context.
+func (s *CampaignCriterionService) QueryWithContext(ctx context.Context, query string, opts ...CallOption) (campaignCriterions CampaignCriterions, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 	respBody, err := s.Auth.request(
+		ctx,
 		campaignCriterionServiceUrl,
 		"query",
 		AWQLQuery{
@@ -280,3 +379,43 @@ func (s *CampaignCriterionService) Query(query string) (campaignCriterions Campa
 	}
 	return getResp.CampaignCriterions, getResp.Size, err
 }
+
+// QueryEach is the same as Query but passes the criteria to fn one at a time
+// as they are decoded, see GetEach.
+func (s *CampaignCriterionService) QueryEach(query string, fn func(interface{}) error, opts ...CallOption) (totalCount int64, err error) {
+	return s.QueryEachWithContext(context.Background(), query, fn, opts...)
+}
+
+// QueryEachWithContext is the same as QueryEach with the addition of a context.
+func (s *CampaignCriterionService) QueryEachWithContext(ctx context.Context, query string, fn func(interface{}) error, opts ...CallOption) (totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
+	return s.Auth.stream(
+		ctx,
+		campaignCriterionServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+		campaignCriterionEntries(fn),
+	)
+}
+
+// campaignCriterionEntries decodes streamed criteria for fn.
+func campaignCriterionEntries(fn func(interface{}) error) entryFunc {
+	return func(dec *xml.Decoder, start *xml.StartElement) error {
+		var campaignCriterions CampaignCriterions
+		if err := dec.DecodeElement(&campaignCriterions, start); err != nil {
+			return err
+		}
+		for _, campaignCriterion := range campaignCriterions {
+			if err := fn(campaignCriterion); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
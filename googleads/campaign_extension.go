@@ -1,6 +1,7 @@
 package v201809
 
 import (
+	"context"
 	"encoding/xml"
 )
 
@@ -23,8 +24,15 @@ type CampaignExtensionSetting struct {
 type CampaignExtensionSettingOperations map[string][]CampaignExtensionSetting
 
 // https://developers.google.com/adwords/api/docs/reference/v201809/CampaignExtensionSettingService#query
-func (s *CampaignExtensionSettingService) Query(query string) (settings []CampaignExtensionSetting, totalCount int64, err error) {
+func (s *CampaignExtensionSettingService) Query(query string, opts ...CallOption) (settings []CampaignExtensionSetting, totalCount int64, err error) {
+	return s.QueryWithContext(context.Background(), query, opts...)
+}
+
+// QueryWithContext is the same as Query with the addition of a context.
+func (s *CampaignExtensionSettingService) QueryWithContext(ctx context.Context, query string, opts ...CallOption) (settings []CampaignExtensionSetting, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 	respBody, err := s.Auth.request(
+		ctx,
 		campaignExtensionSettingUrl,
 		"query",
 		AWQLQuery{
@@ -52,7 +60,13 @@ func (s *CampaignExtensionSettingService) Query(query string) (settings []Campai
 }
 
 // https://developers.google.com/adwords/api/docs/reference/v201809/CampaignExtensionSettingService#mutate
-func (s *CampaignExtensionSettingService) Mutate(settingsOperations CampaignExtensionSettingOperations) (settings []CampaignExtensionSetting, err error) {
+func (s *CampaignExtensionSettingService) Mutate(settingsOperations CampaignExtensionSettingOperations, opts ...CallOption) (settings []CampaignExtensionSetting, err error) {
+	return s.MutateWithContext(context.Background(), settingsOperations, opts...)
+}
+
+// MutateWithContext is the same as Mutate with the addition of a context.
+func (s *CampaignExtensionSettingService) MutateWithContext(ctx context.Context, settingsOperations CampaignExtensionSettingOperations, opts ...CallOption) (settings []CampaignExtensionSetting, err error) {
+	ctx = withCallOptions(ctx, opts)
 	type settingOperations struct {
 		Action  string                   `xml:"operator"`
 		Setting CampaignExtensionSetting `xml:"operand"`
@@ -79,17 +93,18 @@ func (s *CampaignExtensionSettingService) Mutate(settingsOperations CampaignExte
 		Ops: operations,
 	}
 
-	respBody, err := s.Auth.request(campaignExtensionSettingUrl, "mutate", mutation)
+	respBody, err := s.Auth.request(ctx, campaignExtensionSettingUrl, "mutate", mutation)
 	if err != nil {
 		return settings, err
 	}
 	mutateResp := struct {
-		Settings []CampaignExtensionSetting `xml:"rval>value"`
+		Settings             []CampaignExtensionSetting `xml:"rval>value"`
+		PartialFailureErrors partialFailureErrors       `xml:"rval>partialFailureErrors"`
 	}{}
 	err = xml.Unmarshal(respBody, &mutateResp)
 	if err != nil {
 		return settings, err
 	}
 
-	return mutateResp.Settings, err
+	return mutateResp.Settings, partialFailure(operations, mutateResp.PartialFailureErrors)
 }
@@ -1,6 +1,9 @@
 package v201809
 
-import "encoding/xml"
+import (
+	"context"
+	"encoding/xml"
+)
 
 type CampaignSharedSetService struct {
 	Auth
@@ -24,9 +27,16 @@ type CampaignSharedSetOperation struct {
 	Operand  CampaignSharedSet `xml:"operand,omitempty"`
 }
 
-func (s CampaignSharedSetService) Get(selector Selector) (sharedSets []CampaignSharedSet, totalCount int64, err error) {
+func (s CampaignSharedSetService) Get(selector Selector, opts ...CallOption) (sharedSets []CampaignSharedSet, totalCount int64, err error) {
+	return s.GetWithContext(context.Background(), selector, opts...)
+}
+
+// GetWithContext is the same as Get with the addition of a context.
+func (s CampaignSharedSetService) GetWithContext(ctx context.Context, selector Selector, opts ...CallOption) (sharedSets []CampaignSharedSet, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 	selector.XMLName = xml.Name{baseUrl, "selector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		campaignSharedSetServiceUrl,
 		"get",
 		struct {
@@ -54,7 +64,13 @@ func (s CampaignSharedSetService) Get(selector Selector) (sharedSets []CampaignS
 	return getResp.SharedSets, getResp.Size, err
 }
 
-func (s CampaignSharedSetService) Mutate(operations []CampaignSharedSetOperation) error {
+func (s CampaignSharedSetService) Mutate(operations []CampaignSharedSetOperation, opts ...CallOption) error {
+	return s.MutateWithContext(context.Background(), operations, opts...)
+}
+
+// MutateWithContext is the same as Mutate with the addition of a context.
+func (s CampaignSharedSetService) MutateWithContext(ctx context.Context, operations []CampaignSharedSetOperation, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
 	mutateRequest := struct {
 		XMLName xml.Name
 		Ops     []CampaignSharedSetOperation `xml:"operations"`
@@ -64,6 +80,15 @@ func (s CampaignSharedSetService) Mutate(operations []CampaignSharedSetOperation
 			Local: "mutate",
 		},
 		Ops: operations}
-	_, err := s.Auth.request(campaignSharedSetServiceUrl, "mutate", mutateRequest)
-	return err
+	respBody, err := s.Auth.request(ctx, campaignSharedSetServiceUrl, "mutate", mutateRequest)
+	if err != nil {
+		return err
+	}
+	mutateResp := struct {
+		PartialFailureErrors partialFailureErrors `xml:"rval>partialFailureErrors"`
+	}{}
+	if err := xml.Unmarshal(respBody, &mutateResp); err != nil {
+		return err
+	}
+	return partialFailure(operations, mutateResp.PartialFailureErrors)
 }
@@ -0,0 +1,273 @@
+package v201809
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Interaction is a request and its response recorded in a Cassette.
+type Interaction struct {
+	Service     string // e.g. CampaignService, ReportDownloadService
+	Action      string // SOAP action, "download" for reports, else the HTTP method
+	Method      string
+	Url         string // without query, temporary urls are signed there
+	RequestBody string // redacted and normalized
+
+	Status         int
+	ResponseHeader http.Header
+	ResponseBody   string // redacted and decompressed
+}
+
+// Cassette holds the interactions recorded by a RecordingClient for a
+// ReplayingClient to serve, e.g. in tests which run without credentials.
+type Cassette struct {
+	Interactions []Interaction
+}
+
+// LoadCassette reads a cassette saved by Save.
+func LoadCassette(file string) (*Cassette, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	c := &Cassette{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("cassette %s: %v", file, err)
+	}
+	return c, nil
+}
+
+// Save writes the cassette to file as JSON.
+func (c *Cassette) Save(file string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, data, 0644)
+}
+
+var (
+	// ids of the account operated on
+	redactCustomerIds = regexp.MustCompile(`(?s)(<(?:[\w-]+:)?clientCustomerId\b[^>/]*>).*?(</(?:[\w-]+:)?clientCustomerId>)`)
+	// whitespace between elements, indented and compact requests match
+	interElementSpace = regexp.MustCompile(`>\s+<`)
+)
+
+// redactInteraction replaces the developer token, OAuth credentials and
+// customer ids of a body, and then applies redact if set.
+func redactInteraction(body []byte, redact func([]byte) []byte) []byte {
+	body = RedactBody(body)
+	body = redactCustomerIds.ReplaceAll(body, []byte("${1}"+redactedValue+"${2}"))
+	if redact != nil {
+		body = redact(body)
+	}
+	return body
+}
+
+// normalizeRequestBody returns the form of a request body interactions are
+// matched on.  Report download forms are sorted and element whitespace is
+// dropped.
+func normalizeRequestBody(req *http.Request, body []byte, redact func([]byte) []byte) string {
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			for k, vs := range form {
+				for i, v := range vs {
+					vs[i] = strings.TrimSpace(interElementSpace.ReplaceAllString(v, "><"))
+				}
+				form[k] = vs
+			}
+			body = []byte(form.Encode())
+		}
+	}
+	body = redactInteraction(body, redact)
+	return strings.TrimSpace(interElementSpace.ReplaceAllString(string(body), "><"))
+}
+
+// interactionKey returns the service and action of req.
+func interactionKey(req *http.Request) (service, action string) {
+	service = path.Base(req.URL.Path)
+	if strings.Contains(req.URL.Path, "/reportdownload/") {
+		return "ReportDownloadService", "download"
+	}
+	if action = req.Header.Get("SOAPAction"); action == "" {
+		action = req.Method
+	}
+	return service, action
+}
+
+// readRequestBody reads the body of req and puts it back for sending.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// RecordingClient is an HttpClient recording the exchanges of Client in a
+// cassette.  Developer tokens, OAuth credentials and customer ids are
+// redacted from the recorded bodies, Redact may remove more.
+//
+// Example
+//
+//   recorder := gads.NewRecordingClient(auth.Client)
+//   auth.Client = recorder
+//   ... make calls ...
+//   err := recorder.Cassette().Save("testdata/campaigns.json")
+//
+type RecordingClient struct {
+	Client HttpClient
+	Redact func(body []byte) []byte
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecordingClient returns a RecordingClient sending requests through
+// client.
+func NewRecordingClient(client HttpClient) *RecordingClient {
+	return &RecordingClient{Client: client}
+}
+
+func (c *RecordingClient) Do(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	reader, err := decodedBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	respBody, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	header := resp.Header.Clone()
+	header.Del("Content-Encoding")
+	header.Del("Content-Length")
+
+	service, action := interactionKey(req)
+	u := *req.URL
+	u.RawQuery = ""
+	c.mu.Lock()
+	c.cassette.Interactions = append(c.cassette.Interactions, Interaction{
+		Service:        service,
+		Action:         action,
+		Method:         req.Method,
+		Url:            u.String(),
+		RequestBody:    normalizeRequestBody(req, reqBody, c.Redact),
+		Status:         resp.StatusCode,
+		ResponseHeader: header,
+		ResponseBody:   string(redactInteraction(respBody, c.Redact)),
+	})
+	c.mu.Unlock()
+
+	resp.Header = header
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+	resp.ContentLength = int64(len(respBody))
+	return resp, nil
+}
+
+// Cassette returns a copy of the interactions recorded so far.
+func (c *RecordingClient) Cassette() *Cassette {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &Cassette{Interactions: append([]Interaction(nil), c.cassette.Interactions...)}
+}
+
+// ReplayingClient is an HttpClient answering requests from a cassette.  A
+// request is answered by the first unused interaction of the same service
+// and action whose normalized and redacted body matches, requests without
+// one fail with an UnmatchedRequestError.
+type ReplayingClient struct {
+	Redact func(body []byte) []byte
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// NewReplayingClient returns a ReplayingClient serving cassette.
+func NewReplayingClient(cassette *Cassette) *ReplayingClient {
+	return &ReplayingClient{
+		cassette: cassette,
+		used:     make([]bool, len(cassette.Interactions)),
+	}
+}
+
+// UnmatchedRequestError is returned by a ReplayingClient for requests not
+// recorded in its cassette.
+type UnmatchedRequestError struct {
+	Service     string
+	Action      string
+	RequestBody string // normalized and redacted
+}
+
+func (e *UnmatchedRequestError) Error() string {
+	return fmt.Sprintf("gads: no recorded interaction for %s %s with request body\n%s", e.Service, e.Action, e.RequestBody)
+}
+
+func (c *ReplayingClient) Do(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	service, action := interactionKey(req)
+	body := normalizeRequestBody(req, reqBody, c.Redact)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, in := range c.cassette.Interactions {
+		if c.used[i] || in.Service != service || in.Action != action || in.RequestBody != body {
+			continue
+		}
+		c.used[i] = true
+		header := in.ResponseHeader.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Status, http.StatusText(in.Status)),
+			StatusCode:    in.Status,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(in.ResponseBody)),
+			ContentLength: int64(len(in.ResponseBody)),
+			Request:       req,
+		}, nil
+	}
+	return nil, &UnmatchedRequestError{Service: service, Action: action, RequestBody: body}
+}
+
+// Unused returns the interactions no request has been answered with yet,
+// tests may check it is empty to make sure all expected calls were made.
+func (c *ReplayingClient) Unused() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var unused []Interaction
+	for i, in := range c.cassette.Interactions {
+		if !c.used[i] {
+			unused = append(unused, in)
+		}
+	}
+	return unused
+}
@@ -0,0 +1,80 @@
+package v201809
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCassetteRecordReplay(t *testing.T) {
+	client := &countingClient{status: 200, body: soapGetResponse(`<entries><id>1</id><name>one</name></entries>`)}
+	recorder := NewRecordingClient(client)
+	auth := &Auth{Client: recorder, DeveloperToken: "secret-token", CustomerId: "111-111-1111"}
+
+	selector := Selector{Fields: []string{"Id", "Name"}}
+	if _, _, err := NewCampaignService(auth).Get(selector); err != nil {
+		t.Fatal(err)
+	}
+	client.body = "Day,Clicks\n2018-01-01,5\n"
+	if _, err := NewReportDownloadService(auth).AWQL("SELECT Date, Clicks FROM ACCOUNT_PERFORMANCE_REPORT", "CSV"); err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "gads-cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "cassette.json")
+	if err := recorder.Cassette().Save(file); err != nil {
+		t.Fatal(err)
+	}
+	saved, _ := ioutil.ReadFile(file)
+	for _, secret := range []string{"secret-token", "111-111-1111"} {
+		if strings.Contains(string(saved), secret) {
+			t.Errorf("expected %s to be redacted from\n%s", secret, saved)
+		}
+	}
+
+	cassette, err := LoadCassette(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cassette.Interactions) != 2 || cassette.Interactions[0].Service != "CampaignService" || cassette.Interactions[0].Action != "get" {
+		t.Fatalf("got %+v", cassette.Interactions)
+	}
+
+	// credentials and accounts do not need to match the recording
+	replayer := NewReplayingClient(cassette)
+	auth = &Auth{Client: replayer, DeveloperToken: "other-token", CustomerId: "222-222-2222"}
+	campaigns, _, err := NewCampaignService(auth).Get(selector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(campaigns) != 1 || campaigns[0].Name != "one" {
+		t.Errorf("got %+v", campaigns)
+	}
+	rows, err := NewReportDownloadService(auth).AWQL("SELECT Date, Clicks FROM ACCOUNT_PERFORMANCE_REPORT", "CSV")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := rows.([]map[string]string); len(r) != 1 || r[0]["Clicks"] != "5" {
+		t.Errorf("got %+v", rows)
+	}
+	if unused := replayer.Unused(); len(unused) != 0 {
+		t.Errorf("got unused interactions %+v", unused)
+	}
+
+	// every interaction answers a single request
+	_, _, err = NewCampaignService(auth).Get(selector)
+	var unmatched *UnmatchedRequestError
+	if !errors.As(err, &unmatched) || unmatched.Service != "CampaignService" || unmatched.Action != "get" {
+		t.Fatalf("expected an UnmatchedRequestError, got %v", err)
+	}
+	if client.calls != 2 {
+		t.Errorf("expected replays not to reach the client, got %d calls", client.calls)
+	}
+}
@@ -0,0 +1,243 @@
+package v201809
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// faultErrors returns the FaultErrors found in err and the errors it wraps,
+// including the ApiError of failed report downloads.
+func faultErrors(err error) (faults []FaultError) {
+	if fe, ok := err.(FaultError); ok {
+		faults = append(faults, fe)
+	}
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, err := range e.Unwrap() {
+			faults = append(faults, faultErrors(err)...)
+		}
+	case interface{ Unwrap() error }:
+		if err := e.Unwrap(); err != nil {
+			faults = append(faults, faultErrors(err)...)
+		}
+	}
+	return faults
+}
+
+// hasFault reports whether err holds a FaultError for whose type and
+// reason match returns true.
+func hasFault(err error, match func(errorType, reason string) bool) bool {
+	for _, fe := range faultErrors(err) {
+		if match(fe.ErrorType(), fe.ErrorReason()) {
+			return true
+		}
+	}
+	return false
+}
+
+// httpStatus returns the status of an HTTPError in err, or 0.
+func httpStatus(err error) int {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode
+	}
+	return 0
+}
+
+// IsRetryable reports whether err is transient and the call may succeed if
+// made again later: rate limiting, internal and database errors on Google's
+// side, HTTP 429 and 5xx responses and network failures.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	return isTransientError(err) || hasFault(err, func(errorType, reason string) bool {
+		switch errorType {
+		case "RateExceededError", "InternalApiError", "DatabaseError":
+			return true
+		}
+		return false
+	})
+}
+
+// IsAuthError reports whether err is due to the credentials: failed OAuth2
+// token refreshes, AuthenticationErrors, AuthorizationErrors and access
+// denied to the account or an entity.
+func IsAuthError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) {
+		return true
+	}
+	if status := httpStatus(err); status == http.StatusUnauthorized || status == http.StatusForbidden {
+		return true
+	}
+	return hasFault(err, func(errorType, reason string) bool {
+		switch errorType {
+		case "AuthenticationError", "AuthorizationError", "EntityAccessDenied", "OperationAccessDenied", "ClientTermsError":
+			return true
+		case "CustomerError":
+			return reason == "INVALID_CUSTOMER_ID" || reason == "CUSTOMER_NOT_ACTIVE"
+		}
+		return false
+	})
+}
+
+// IsQuotaError reports whether err is due to a quota or limit: rate limits,
+// the API quota of the developer token and limits on the number of
+// entities.
+func IsQuotaError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if httpStatus(err) == http.StatusTooManyRequests {
+		return true
+	}
+	return hasFault(err, func(errorType, reason string) bool {
+		switch errorType {
+		case "RateExceededError", "QuotaCheckError", "EntityCountLimitExceeded", "AdGroupAdCountLimitExceeded",
+			"AdGroupCriterionLimitExceeded", "CampaignCriterionLimitExceeded", "SizeLimitError":
+			return true
+		}
+		return false
+	})
+}
+
+// IsValidationError reports whether err is due to invalid input that must
+// be fixed before sending the request again, e.g. a RequiredError or a
+// PolicyViolationError.
+func IsValidationError(err error) bool {
+	if err == nil {
+		return false
+	}
+	return hasFault(err, func(errorType, reason string) bool {
+		switch errorType {
+		case "RequiredError", "RangeError", "StringLengthError", "StringFormatError", "NotEmptyError",
+			"NullError", "DistinctError", "ReadOnlyError", "IdError", "OperatorError", "SelectorError",
+			"QueryError", "FieldPathError", "CollectionSizeError", "DateError", "DateRangeError",
+			"RegionCodeError", "CurrencyCodeError", "UrlError", "PolicyViolationError", "PolicyFindingError",
+			"ReportDefinitionError", "RequestError", "RejectedError", "PagingError":
+			return true
+		}
+		return false
+	})
+}
+
+// IsNotFound reports whether err is due to an entity that does not exist,
+// e.g. EntityNotFound.INVALID_ID.
+func IsNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	return hasFault(err, func(errorType, reason string) bool {
+		switch errorType {
+		case "EntityNotFound":
+			return true
+		case "IdError":
+			return reason == "NOT_FOUND"
+		case "AdGroupAdError":
+			return reason == "AD_GROUP_AD_LABEL_DOES_NOT_EXIST"
+		case "AdGroupCriterionError":
+			return reason == "AD_GROUP_CRITERION_LABEL_DOES_NOT_EXIST"
+		}
+		return false
+	})
+}
+
+// Remediation explains an error reason and how to address it.
+type Remediation struct {
+	Error       string // type and reason, e.g. EntityNotFound.INVALID_ID
+	Explanation string
+	Suggestion  string
+}
+
+// remediations is the catalog of known error reasons by Type.REASON, or by
+// Type for the reasons of a type.
+var remediations = map[string]Remediation{}
+
+func init() {
+	for _, r := range []Remediation{
+		{"AuthenticationError.GOOGLE_ACCOUNT_COOKIE_INVALID", "The OAuth2 access token is invalid or expired.", "Refresh the access token, or authorize the application again if the refresh token was revoked."},
+		{"AuthenticationError.OAUTH_TOKEN_INVALID", "The OAuth2 access token is invalid.", "Refresh the access token, or authorize the application again if the refresh token was revoked."},
+		{"AuthenticationError.OAUTH_TOKEN_EXPIRED", "The OAuth2 access token expired.", "Refresh the access token before making the call again."},
+		{"AuthenticationError.OAUTH_TOKEN_REVOKED", "The user revoked the access of the application.", "Have the user authorize the application again."},
+		{"AuthenticationError.NOT_ADS_USER", "The Google account of the credentials has no AdWords account.", "Authorize with an account that has access to AdWords."},
+		{"AuthenticationError.CLIENT_CUSTOMER_ID_INVALID", "The clientCustomerId header is not a valid customer id.", "Set Auth.CustomerId, or use WithCustomerId, to the 10 digit id of the account."},
+		{"AuthenticationError.CLIENT_CUSTOMER_ID_IS_REQUIRED", "The call requires a clientCustomerId header.", "Set Auth.CustomerId, or use WithCustomerId, to the id of the account to operate on."},
+		{"AuthorizationError.USER_PERMISSION_DENIED", "The authorized user has no access to the account in clientCustomerId.", "Check the customer id and that the user, or a manager account of the user, is linked to the account."},
+		{"AuthorizationError.DEVELOPER_TOKEN_NOT_WHITELISTED_FOR_BETA", "The developer token is not allowed to use a beta feature.", "Request access to the beta for the developer token."},
+		{"AuthorizationError.DEVELOPER_TOKEN_NOT_APPROVED", "The developer token is only approved for test accounts.", "Use a test account, or apply for Basic access to the developer token."},
+		{"AuthorizationError.CUSTOMER_NOT_ENABLED", "The account is not enabled, e.g. it was cancelled or is not yet set up.", "Finish the setup of the account or enable it again in the AdWords UI."},
+		{"AuthorizationError.NO_CUSTOMER_FOUND", "No account exists for clientCustomerId.", "Check the customer id."},
+		{"CustomerError.INVALID_CUSTOMER_ID", "The clientCustomerId is not a valid customer id.", "Check the customer id."},
+		{"EntityAccessDenied", "The account has no access to the entity.", "Check that the ids belong to the account in clientCustomerId."},
+		{"EntityNotFound.INVALID_ID", "No entity exists with the given id, or it belongs to another account.", "Check the ids and clientCustomerId, the entity may have been removed."},
+		{"EntityNotFound", "The entity referred to does not exist.", "Check the ids and clientCustomerId, the entity may have been removed."},
+		{"RateExceededError.RATE_EXCEEDED", "Too many requests were made in a short time.", "Wait for RetryAfterSeconds before sending the request again and reduce the request rate, see RateLimiter."},
+		{"QuotaCheckError.QUOTA_EXCEEDED", "The developer token exceeded its daily quota of operations.", "Wait until the quota resets or apply for Standard access to the developer token."},
+		{"QuotaCheckError.ACCOUNT_DELINQUENT", "The account has unpaid bills.", "Settle the billing of the account."},
+		{"QuotaCheckError.ACCOUNT_INACCESSIBLE", "The account is inaccessible, e.g. it was suspended.", "Check the status of the account in the AdWords UI."},
+		{"QuotaCheckError.INVALID_TOKEN_HEADER", "The developer token is invalid.", "Check Auth.DeveloperToken."},
+		{"QuotaCheckError.DEVELOPER_TOKEN_NOT_APPROVED", "The developer token is pending approval and can only be used with test accounts.", "Use a test account or wait for the approval."},
+		{"EntityCountLimitExceeded", "The operation would exceed the maximum number of entities allowed.", "Remove unused entities, e.g. paused or removed ads and keywords, before adding new ones."},
+		{"SizeLimitError.RESPONSE_SIZE_LIMIT_EXCEEDED", "The response would be too large.", "Request fewer fields or a smaller page with the selector."},
+		{"SizeLimitError.REQUEST_SIZE_LIMIT_EXCEEDED", "The request is too large.", "Send fewer operations per mutate."},
+		{"InternalApiError.UNEXPECTED_INTERNAL_API_ERROR", "An unexpected error occurred on Google's side.", "Retry later with exponential backoff, see RetryPolicy."},
+		{"InternalApiError.TRANSIENT_ERROR", "A transient error occurred on Google's side.", "Retry later with exponential backoff, see RetryPolicy."},
+		{"DatabaseError.CONCURRENT_MODIFICATION", "The entity was modified by another request at the same time.", "Retry the request, avoiding concurrent changes to the same entities."},
+		{"DatabaseError.DATA_CONSTRAINT_VIOLATION", "The request conflicts with the state of other entities.", "Check the request against the current state of the account."},
+		{"RequiredError.REQUIRED", "A required field is missing.", "Set the field named by the field path."},
+		{"RangeError.TOO_LOW", "A value is below its minimum.", "Increase the value of the field named by the field path."},
+		{"RangeError.TOO_HIGH", "A value exceeds its maximum.", "Decrease the value of the field named by the field path."},
+		{"StringLengthError.TOO_LONG", "A string exceeds its maximum length.", "Shorten the field named by the field path."},
+		{"StringLengthError.TOO_SHORT", "A string is shorter than its minimum length.", "Lengthen the field named by the field path."},
+		{"NotEmptyError.EMPTY_LIST", "A list must not be empty.", "Add at least one element to the field named by the field path."},
+		{"OperatorError.OPERATOR_NOT_SUPPORTED", "The operator is not supported for the entity.", "Use another operator, e.g. SET to remove some entities by status."},
+		{"SelectorError.INVALID_FIELD_NAME", "The selector asks for an unknown field.", "Check the field names against the selectable fields of the service."},
+		{"SelectorError.INVALID_PREDICATE_FIELD_NAME", "A predicate refers to an unknown or non filterable field.", "Check the predicate fields against the filterable fields of the service."},
+		{"QueryError.PARSING_FAILED", "The AWQL query is malformed.", "Check the query syntax."},
+		{"PolicyViolationError.POLICY_ERROR", "The ad or keyword violates an advertising policy.", "Change the text, or request an exemption if the violation is exemptable, see ExemptPolicyViolations."},
+		{"PagingError.INVALID_PAGE_OFFSET", "The page offset exceeds the maximum of 100,000.", "Filter the selector, e.g. on ranges of ids, to page through fewer entries."},
+		{"ReportDefinitionError.INVALID_FIELD_NAME_FOR_REPORT", "The report does not have one of the requested fields.", "Check the fields against the report definition."},
+		{"ReportDefinitionError.INVALID_DATE_RANGE_FOR_REPORT", "The date range is invalid for the report.", "Check the date range type and the min and max dates."},
+		{"ReportDefinitionError.CUSTOMER_SERVING_TYPE_REPORT_MISMATCH", "The report cannot be downloaded for a manager account.", "Download the report of each client account instead."},
+		{"CampaignError.DUPLICATE_CAMPAIGN_NAME", "Another campaign of the account has the same name.", "Use a unique name, removed campaigns keep their names."},
+		{"BudgetError.DUPLICATE_NAME", "Another budget of the account has the same name.", "Use a unique name for explicitly shared budgets."},
+		{"CriterionError.KEYWORD_HAS_INVALID_CHARS", "The keyword text contains characters not allowed in keywords.", "Remove the invalid characters from the keyword text."},
+	} {
+		remediations[r.Error] = r
+	}
+}
+
+// LookupRemediation returns the remediation of an error given as Type.REASON,
+// e.g. AuthorizationError.USER_PERMISSION_DENIED, falling back to one for
+// any reason of Type.
+func LookupRemediation(errorString string) (Remediation, bool) {
+	if r, ok := remediations[errorString]; ok {
+		return r, true
+	}
+	r, ok := remediations[strings.SplitN(errorString, ".", 2)[0]]
+	return r, ok
+}
+
+// Remediations returns the remediations of the errors in err known to the
+// catalog, once per error reason.
+func Remediations(err error) (rs []Remediation) {
+	seen := map[string]bool{}
+	for _, fe := range faultErrors(err) {
+		errorString := fe.ErrorType() + "." + fe.ErrorReason()
+		if seen[errorString] {
+			continue
+		}
+		seen[errorString] = true
+		if r, ok := LookupRemediation(errorString); ok {
+			rs = append(rs, r)
+		}
+	}
+	return rs
+}
@@ -0,0 +1,80 @@
+package v201809
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestClassifyErrors(t *testing.T) {
+	fault := func(errorType, reason string) error {
+		client := &countingClient{status: 500, body: testFault(errorType, reason, "")}
+		_, _, err := NewCampaignService(&Auth{Client: client, RetryPolicy: NoRetry}).Get(Selector{})
+		return err
+	}
+	reportErr := func(errorType string) error {
+		body := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><reportDownloadError><ApiError><type>` + errorType + `</type></ApiError></reportDownloadError>`
+		client := &TestClient{res: &http.Response{Body: ioutil.NopCloser(bytes.NewBufferString(body)), StatusCode: 400}}
+		// report downloads are retried by default, like SOAP calls
+		_, err := NewReportDownloadService(&Auth{Client: client, RetryPolicy: NoRetry}).Get(ReportDefinition{})
+		return err
+	}
+
+	classifiers := map[string]func(error) bool{
+		"retryable":  IsRetryable,
+		"auth":       IsAuthError,
+		"quota":      IsQuotaError,
+		"validation": IsValidationError,
+		"not found":  IsNotFound,
+	}
+	for _, tt := range []struct {
+		name     string
+		err      error
+		expected []string
+	}{
+		{"rate exceeded", fault("RateExceededError", "RATE_EXCEEDED"), []string{"retryable", "quota"}},
+		{"internal", fault("InternalApiError", "UNEXPECTED_INTERNAL_API_ERROR"), []string{"retryable"}},
+		{"authentication", fault("AuthenticationError", "OAUTH_TOKEN_INVALID"), []string{"auth"}},
+		{"report authorization", reportErr("AuthorizationError.USER_PERMISSION_DENIED"), []string{"auth"}},
+		{"report rate exceeded", reportErr("RateExceededError.RATE_EXCEEDED"), []string{"retryable", "quota"}},
+		{"quota", fault("QuotaCheckError", "QUOTA_EXCEEDED"), []string{"quota"}},
+		{"required", fault("RequiredError", "REQUIRED"), []string{"validation"}},
+		{"not found", fault("EntityNotFound", "INVALID_ID"), []string{"not found"}},
+		{"id not found", fault("IdError", "NOT_FOUND"), []string{"validation", "not found"}},
+		{"label not found", fault("AdGroupCriterionError", "AD_GROUP_CRITERION_LABEL_DOES_NOT_EXIST"), []string{"not found"}},
+		{"other reason ending in NOT_FOUND", fault("ReportDefinitionError", "FIELD_NOT_FOUND"), []string{"validation"}},
+		{"other type ending in NOT_FOUND", fault("CampaignError", "NOT_FOUND"), nil},
+		{"http 503", &HTTPError{StatusCode: 503}, []string{"retryable"}},
+		{"other", errors.New("other"), nil},
+		{"nil", nil, nil},
+	} {
+		for name, classify := range classifiers {
+			expected := false
+			for _, e := range tt.expected {
+				expected = expected || e == name
+			}
+			if classify(tt.err) != expected {
+				t.Errorf("%s: expected %s to be %v", tt.name, name, expected)
+			}
+		}
+	}
+}
+
+func TestRemediations(t *testing.T) {
+	client := &countingClient{status: 500, body: testFault("EntityNotFound", "INVALID_ID", "")}
+	_, _, err := NewCampaignService(&Auth{Client: client, RetryPolicy: NoRetry}).Get(Selector{})
+
+	rs := Remediations(err)
+	if len(rs) != 1 || rs[0].Error != "EntityNotFound.INVALID_ID" || rs[0].Suggestion == "" {
+		t.Errorf("got %+v", rs)
+	}
+
+	if r, ok := LookupRemediation("EntityNotFound.UNKNOWN_REASON"); !ok || r.Error != "EntityNotFound" {
+		t.Errorf("expected a fallback to the error type, got %+v", r)
+	}
+	if _, ok := LookupRemediation("BrandNewError.SOMETHING"); ok {
+		t.Error("expected no remediation")
+	}
+}
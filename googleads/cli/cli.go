@@ -46,7 +46,7 @@ func main() {
 		}
 		offset += pageSize
 		paging.Offset = offset
-		if totalCount < offset {
+		if totalCount <= offset {
 			break
 		}
 	}
@@ -95,7 +95,7 @@ func main() {
 		}
 		offset += pageSize
 		paging.Offset = offset
-		if totalCount < offset {
+		if totalCount <= offset {
 			break
 		}
 	}
@@ -137,7 +137,7 @@ func main() {
 		}
 		offset += pageSize
 		paging.Offset = offset
-		if totalCount < offset {
+		if totalCount <= offset {
 			break
 		}
 	}
@@ -175,7 +175,7 @@ func main() {
 		}
 		offset += pageSize
 		paging.Offset = offset
-		if totalCount < offset {
+		if totalCount <= offset {
 			break
 		}
 	}
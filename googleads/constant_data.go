@@ -1,6 +1,7 @@
 package v201809
 
 import (
+	"context"
 	"encoding/xml"
 )
 
@@ -12,8 +13,15 @@ func NewConstantDataService(auth *Auth) *ConstantDataService {
 	return &ConstantDataService{Auth: *auth}
 }
 
-func (s *ConstantDataService) GetAgeRangeCriterion() (ageRanges []AgeRangeCriterion, err error) {
+func (s *ConstantDataService) GetAgeRangeCriterion(opts ...CallOption) (ageRanges []AgeRangeCriterion, err error) {
+	return s.GetAgeRangeCriterionWithContext(context.Background(), opts...)
+}
+
+// GetAgeRangeCriterionWithContext is the same as GetAgeRangeCriterion with the addition of a context.
+func (s *ConstantDataService) GetAgeRangeCriterionWithContext(ctx context.Context, opts ...CallOption) (ageRanges []AgeRangeCriterion, err error) {
+	ctx = withCallOptions(ctx, opts)
 	respBody, err := s.Auth.request(
+		ctx,
 		constantDataServiceUrl,
 		"getAgeRangeCriterion",
 		struct {
@@ -33,8 +41,15 @@ func (s *ConstantDataService) GetAgeRangeCriterion() (ageRanges []AgeRangeCriter
 	return getResp.AgeRangeCriterions, err
 }
 
-func (s *ConstantDataService) GetCarrierCriterion() (carriers []CarrierCriterion, err error) {
+func (s *ConstantDataService) GetCarrierCriterion(opts ...CallOption) (carriers []CarrierCriterion, err error) {
+	return s.GetCarrierCriterionWithContext(context.Background(), opts...)
+}
+
+// GetCarrierCriterionWithContext is the same as GetCarrierCriterion with the addition of a context.
+func (s *ConstantDataService) GetCarrierCriterionWithContext(ctx context.Context, opts ...CallOption) (carriers []CarrierCriterion, err error) {
+	ctx = withCallOptions(ctx, opts)
 	respBody, err := s.Auth.request(
+		ctx,
 		constantDataServiceUrl,
 		"getCarrierCriterion",
 		struct {
@@ -54,8 +69,15 @@ func (s *ConstantDataService) GetCarrierCriterion() (carriers []CarrierCriterion
 	return getResp.CarrierCriterions, err
 }
 
-func (s *ConstantDataService) GetGenderCriterion() (genders []GenderCriterion, err error) {
+func (s *ConstantDataService) GetGenderCriterion(opts ...CallOption) (genders []GenderCriterion, err error) {
+	return s.GetGenderCriterionWithContext(context.Background(), opts...)
+}
+
+// GetGenderCriterionWithContext is the same as GetGenderCriterion with the addition of a context.
+func (s *ConstantDataService) GetGenderCriterionWithContext(ctx context.Context, opts ...CallOption) (genders []GenderCriterion, err error) {
+	ctx = withCallOptions(ctx, opts)
 	respBody, err := s.Auth.request(
+		ctx,
 		constantDataServiceUrl,
 		"getGenderCriterion",
 		struct {
@@ -75,8 +97,15 @@ func (s *ConstantDataService) GetGenderCriterion() (genders []GenderCriterion, e
 	return getResp.GenderCriterions, err
 }
 
-func (s *ConstantDataService) GetLanguageCriterion() (languages []LanguageCriterion, err error) {
+func (s *ConstantDataService) GetLanguageCriterion(opts ...CallOption) (languages []LanguageCriterion, err error) {
+	return s.GetLanguageCriterionWithContext(context.Background(), opts...)
+}
+
+// GetLanguageCriterionWithContext is the same as GetLanguageCriterion with the addition of a context.
+func (s *ConstantDataService) GetLanguageCriterionWithContext(ctx context.Context, opts ...CallOption) (languages []LanguageCriterion, err error) {
+	ctx = withCallOptions(ctx, opts)
 	respBody, err := s.Auth.request(
+		ctx,
 		constantDataServiceUrl,
 		"getLanguageCriterion",
 		struct {
@@ -96,8 +125,15 @@ func (s *ConstantDataService) GetLanguageCriterion() (languages []LanguageCriter
 	return getResp.LanguageCriterions, err
 }
 
-func (s *ConstantDataService) GetMobileDeviceCriterion() (mobileDevices []MobileDeviceCriterion, err error) {
+func (s *ConstantDataService) GetMobileDeviceCriterion(opts ...CallOption) (mobileDevices []MobileDeviceCriterion, err error) {
+	return s.GetMobileDeviceCriterionWithContext(context.Background(), opts...)
+}
+
+// GetMobileDeviceCriterionWithContext is the same as GetMobileDeviceCriterion with the addition of a context.
+func (s *ConstantDataService) GetMobileDeviceCriterionWithContext(ctx context.Context, opts ...CallOption) (mobileDevices []MobileDeviceCriterion, err error) {
+	ctx = withCallOptions(ctx, opts)
 	respBody, err := s.Auth.request(
+		ctx,
 		constantDataServiceUrl,
 		"getMobileDeviceCriterion",
 		struct {
@@ -117,8 +153,15 @@ func (s *ConstantDataService) GetMobileDeviceCriterion() (mobileDevices []Mobile
 	return getResp.MobileDeviceCriterions, err
 }
 
-func (s *ConstantDataService) GetOperatingSystemVersionCriterion() (operatingSystemVersions []OperatingSystemVersionCriterion, err error) {
+func (s *ConstantDataService) GetOperatingSystemVersionCriterion(opts ...CallOption) (operatingSystemVersions []OperatingSystemVersionCriterion, err error) {
+	return s.GetOperatingSystemVersionCriterionWithContext(context.Background(), opts...)
+}
+
+// GetOperatingSystemVersionCriterionWithContext is the same as GetOperatingSystemVersionCriterion with the addition of a context.
+func (s *ConstantDataService) GetOperatingSystemVersionCriterionWithContext(ctx context.Context, opts ...CallOption) (operatingSystemVersions []OperatingSystemVersionCriterion, err error) {
+	ctx = withCallOptions(ctx, opts)
 	respBody, err := s.Auth.request(
+		ctx,
 		constantDataServiceUrl,
 		"getOperatingSystemVersionCriterion",
 		struct {
@@ -138,10 +181,17 @@ func (s *ConstantDataService) GetOperatingSystemVersionCriterion() (operatingSys
 	return getResp.OperatingSystemVersionCriterions, err
 }
 
-func (s *ConstantDataService) GetProductBiddingCategoryCriterion(selector Selector) (categoryData []ProductBiddingCategoryData, err error) {
+func (s *ConstantDataService) GetProductBiddingCategoryCriterion(selector Selector, opts ...CallOption) (categoryData []ProductBiddingCategoryData, err error) {
+	return s.GetProductBiddingCategoryCriterionWithContext(context.Background(), selector, opts...)
+}
+
+// GetProductBiddingCategoryCriterionWithContext is the same as GetProductBiddingCategoryCriterion with the addition of a context.
+func (s *ConstantDataService) GetProductBiddingCategoryCriterionWithContext(ctx context.Context, selector Selector, opts ...CallOption) (categoryData []ProductBiddingCategoryData, err error) {
+	ctx = withCallOptions(ctx, opts)
 	selector.XMLName = xml.Name{baseUrl, "selector"}
 
 	respBody, err := s.Auth.request(
+		ctx,
 		constantDataServiceUrl,
 		"getProductBiddingCategoryData",
 		struct {
@@ -168,8 +218,15 @@ func (s *ConstantDataService) GetProductBiddingCategoryCriterion(selector Select
 	return getResp.ProductBiddingCategoryDatas, err
 }
 
-func (s *ConstantDataService) GetUserInterestCriterion() (userInterests []UserInterestCriterion, err error) {
+func (s *ConstantDataService) GetUserInterestCriterion(opts ...CallOption) (userInterests []UserInterestCriterion, err error) {
+	return s.GetUserInterestCriterionWithContext(context.Background(), opts...)
+}
+
+// GetUserInterestCriterionWithContext is the same as GetUserInterestCriterion with the addition of a context.
+func (s *ConstantDataService) GetUserInterestCriterionWithContext(ctx context.Context, opts ...CallOption) (userInterests []UserInterestCriterion, err error) {
+	ctx = withCallOptions(ctx, opts)
 	respBody, err := s.Auth.request(
+		ctx,
 		constantDataServiceUrl,
 		"getUserInterestCriterion",
 		struct {
@@ -189,8 +246,15 @@ func (s *ConstantDataService) GetUserInterestCriterion() (userInterests []UserIn
 	return getResp.UserInterestCriterions, err
 }
 
-func (s *ConstantDataService) GetVerticalCriterion() (verticals []VerticalCriterion, err error) {
+func (s *ConstantDataService) GetVerticalCriterion(opts ...CallOption) (verticals []VerticalCriterion, err error) {
+	return s.GetVerticalCriterionWithContext(context.Background(), opts...)
+}
+
+// GetVerticalCriterionWithContext is the same as GetVerticalCriterion with the addition of a context.
+func (s *ConstantDataService) GetVerticalCriterionWithContext(ctx context.Context, opts ...CallOption) (verticals []VerticalCriterion, err error) {
+	ctx = withCallOptions(ctx, opts)
 	respBody, err := s.Auth.request(
+		ctx,
 		constantDataServiceUrl,
 		"getVerticalCriterion",
 		struct {
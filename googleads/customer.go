@@ -1,6 +1,7 @@
 package v201809
 
 import (
+	"context"
 	"encoding/xml"
 )
 
@@ -29,8 +30,15 @@ func NewCustomerService(auth *Auth) *CustomerService {
 	return &CustomerService{Auth: *auth}
 }
 
-func (s *CustomerService) GetCustomers() (customers []Customer, err error) {
+func (s *CustomerService) GetCustomers(opts ...CallOption) (customers []Customer, err error) {
+	return s.GetCustomersWithContext(context.Background(), opts...)
+}
+
+// GetCustomersWithContext is the same as GetCustomers with the addition of a context.
+func (s *CustomerService) GetCustomersWithContext(ctx context.Context, opts ...CallOption) (customers []Customer, err error) {
+	ctx = withCallOptions(ctx, opts)
 	respBody, err := s.Auth.request(
+		ctx,
 		customerServiceUrl,
 		"getCustomers",
 		struct {
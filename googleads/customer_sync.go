@@ -1,6 +1,9 @@
 package v201809
 
-import "encoding/xml"
+import (
+	"context"
+	"encoding/xml"
+)
 
 type CustomerSyncService struct {
 	Auth
@@ -49,10 +52,17 @@ type CustomerSyncSelector struct {
 	FeedIds       *[]int64  `xml:"feedIds,omitempty"`
 }
 
-func (s *CustomerSyncService) Get(selector CustomerSyncSelector) (changeData CustomerChangeData, err error) {
+func (s *CustomerSyncService) Get(selector CustomerSyncSelector, opts ...CallOption) (changeData CustomerChangeData, err error) {
+	return s.GetWithContext(context.Background(), selector, opts...)
+}
+
+// GetWithContext is the same as Get with the addition of a context.
+func (s *CustomerSyncService) GetWithContext(ctx context.Context, selector CustomerSyncSelector, opts ...CallOption) (changeData CustomerChangeData, err error) {
+	ctx = withCallOptions(ctx, opts)
 	selector.XMLName = xml.Name{baseSyncUrl, "selector"}
 
 	respBody, err := s.Auth.request(
+		ctx,
 		customerSyncServiceUrl,
 		"get",
 		struct {
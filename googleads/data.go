@@ -1,6 +1,7 @@
 package v201809
 
 import (
+	"context"
 	"encoding/xml"
 )
 
@@ -81,13 +82,20 @@ type CriterionBidLandscape struct {
 //     https://developers.google.com/adwords/api/docs/reference/v201809/DataService#getadgroupbidlandscape
 //	   https://developers.google.com/adwords/api/docs/appendix/selectorfields#v201809-DataService
 //
-func (s *DataService) GetAdGroupBidLandscape(selector Selector) (adGroupBidLandscapes []AdGroupBidLandscape, totalCount int64, err error) {
+func (s *DataService) GetAdGroupBidLandscape(selector Selector, opts ...CallOption) (adGroupBidLandscapes []AdGroupBidLandscape, totalCount int64, err error) {
+	return s.GetAdGroupBidLandscapeWithContext(context.Background(), selector, opts...)
+}
+
+// GetAdGroupBidLandscapeWithContext is the same as GetAdGroupBidLandscape with the addition of a context.
+func (s *DataService) GetAdGroupBidLandscapeWithContext(ctx context.Context, selector Selector, opts ...CallOption) (adGroupBidLandscapes []AdGroupBidLandscape, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 	// The default namespace, "", will break in 1.5 with the addition of
 	// custom namespace support.  Hence, we have to ensure that the baseUrl is
 	// set again as the proper namespace for the service/serviceSelector element
 	selector.XMLName = xml.Name{baseUrl, "serviceSelector"}
 
 	respBody, err := s.Auth.request(
+		ctx,
 		dataServiceUrl,
 		"getAdGroupBidLandscape",
 		struct {
@@ -115,13 +123,20 @@ func (s *DataService) GetAdGroupBidLandscape(selector Selector) (adGroupBidLands
 	return getResp.AdGroupBidLandscapes, getResp.Size, err
 }
 
-func (s *DataService) GetCampaignCriterionBidLandscape(selector Selector) (ret []CriterionBidLandscape, totalCount int64, err error) {
+func (s *DataService) GetCampaignCriterionBidLandscape(selector Selector, opts ...CallOption) (ret []CriterionBidLandscape, totalCount int64, err error) {
+	return s.GetCampaignCriterionBidLandscapeWithContext(context.Background(), selector, opts...)
+}
+
+// GetCampaignCriterionBidLandscapeWithContext is the same as GetCampaignCriterionBidLandscape with the addition of a context.
+func (s *DataService) GetCampaignCriterionBidLandscapeWithContext(ctx context.Context, selector Selector, opts ...CallOption) (ret []CriterionBidLandscape, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 	// The default namespace, "", will break in 1.5 with the addition of
 	// custom namespace support.  Hence, we have to ensure that the baseUrl is
 	// set again as the proper namespace for the service/serviceSelector element
 	selector.XMLName = xml.Name{baseUrl, "serviceSelector"}
 
 	respBody, err := s.Auth.request(
+		ctx,
 		dataServiceUrl,
 		"getCampaignCriterionBidLandscape",
 		struct {
@@ -184,13 +199,20 @@ func (s *DataService) GetCampaignCriterionBidLandscape(selector Selector) (ret [
 //     https://developers.google.com/adwords/api/docs/reference/v201809/DataService#getcriterionbidlandscape
 //	   https://developers.google.com/adwords/api/docs/appendix/selectorfields#v201809-DataService
 //
-func (s *DataService) GetCriterionBidLandscape(selector Selector) (criterionBidLandscapes []CriterionBidLandscape, totalCount int64, err error) {
+func (s *DataService) GetCriterionBidLandscape(selector Selector, opts ...CallOption) (criterionBidLandscapes []CriterionBidLandscape, totalCount int64, err error) {
+	return s.GetCriterionBidLandscapeWithContext(context.Background(), selector, opts...)
+}
+
+// GetCriterionBidLandscapeWithContext is the same as GetCriterionBidLandscape with the addition of a context.
+func (s *DataService) GetCriterionBidLandscapeWithContext(ctx context.Context, selector Selector, opts ...CallOption) (criterionBidLandscapes []CriterionBidLandscape, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 	// The default namespace, "", will break in 1.5 with the addition of
 	// custom namespace support.  Hence, we have to ensure that the baseUrl is
 	// set again as the proper namespace for the service/serviceSelector element
 	selector.XMLName = xml.Name{baseUrl, "serviceSelector"}
 
 	respBody, err := s.Auth.request(
+		ctx,
 		dataServiceUrl,
 		"getCriterionBidLandscape",
 		struct {
@@ -224,9 +246,16 @@ func (s *DataService) GetCriterionBidLandscape(selector Selector) (criterionBidL
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201809/DataService#queryadgroupbidlandscape
 //
-func (s *DataService) QueryAdGroupBidLandscape(query string) (adGroupBidLandscapes []AdGroupBidLandscape, totalCount int64, err error) {
+func (s *DataService) QueryAdGroupBidLandscape(query string, opts ...CallOption) (adGroupBidLandscapes []AdGroupBidLandscape, totalCount int64, err error) {
+	return s.QueryAdGroupBidLandscapeWithContext(context.Background(), query, opts...)
+}
+
+// QueryAdGroupBidLandscapeWithContext is the same as QueryAdGroupBidLandscape with the addition of a context.
+func (s *DataService) QueryAdGroupBidLandscapeWithContext(ctx context.Context, query string, opts ...CallOption) (adGroupBidLandscapes []AdGroupBidLandscape, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 
 	respBody, err := s.Auth.request(
+		ctx,
 		dataServiceUrl,
 		"queryAdGroupBidLandscape",
 		AWQLQuery{
@@ -260,9 +289,16 @@ func (s *DataService) QueryAdGroupBidLandscape(query string) (adGroupBidLandscap
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201809/DataService#querycriterionbidlandscape
 //
-func (s *DataService) QueryCriterionBidLandscape(query string) (criterionBidLandscapes []CriterionBidLandscape, totalCount int64, err error) {
+func (s *DataService) QueryCriterionBidLandscape(query string, opts ...CallOption) (criterionBidLandscapes []CriterionBidLandscape, totalCount int64, err error) {
+	return s.QueryCriterionBidLandscapeWithContext(context.Background(), query, opts...)
+}
+
+// QueryCriterionBidLandscapeWithContext is the same as QueryCriterionBidLandscape with the addition of a context.
+func (s *DataService) QueryCriterionBidLandscapeWithContext(ctx context.Context, query string, opts ...CallOption) (criterionBidLandscapes []CriterionBidLandscape, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 
 	respBody, err := s.Auth.request(
+		ctx,
 		dataServiceUrl,
 		"queryCriterionBidLandscape",
 		AWQLQuery{
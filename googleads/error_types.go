@@ -0,0 +1,345 @@
+package v201809
+
+import "reflect"
+
+// The ApiError types of the v201809 services, all of them carry the fields of
+// EntityError.  Types with additional fields are declared below, see
+// https://developers.google.com/adwords/api/docs/reference/v201809/CampaignService.ApiError
+// for what their reasons mean.
+type (
+	AdCustomizerError           struct{ EntityError }
+	AdGroupAdError              struct{ EntityError }
+	AdGroupCriterionError       struct{ EntityError }
+	AdGroupFeedError            struct{ EntityError }
+	AdParamError                struct{ EntityError }
+	AdSharingError              struct{ EntityError }
+	AdxError                    struct{ EntityError }
+	AssetError                  struct{ EntityError }
+	AssetLinkError              struct{ EntityError }
+	AuthenticationError         struct{ EntityError }
+	AuthorizationError          struct{ EntityError }
+	BatchJobError               struct{ EntityError }
+	BetaError                   struct{ EntityError }
+	BiddingError                struct{ EntityError }
+	BiddingErrors               struct{ EntityError }
+	BiddingStrategyError        struct{ EntityError }
+	BudgetError                 struct{ EntityError }
+	CampaignCriterionError      struct{ EntityError }
+	CampaignError               struct{ EntityError }
+	CampaignFeedError           struct{ EntityError }
+	CampaignPreferenceError     struct{ EntityError }
+	CampaignSharedSetError      struct{ EntityError }
+	ClientTermsError            struct{ EntityError }
+	CollectionSizeError         struct{ EntityError }
+	ConversionTrackingError     struct{ EntityError }
+	CriterionError              struct{ EntityError }
+	CurrencyCodeError           struct{ EntityError }
+	CustomerError               struct{ EntityError }
+	CustomerFeedError           struct{ EntityError }
+	CustomerSyncError           struct{ EntityError }
+	DatabaseError               struct{ EntityError }
+	DateError                   struct{ EntityError }
+	DateRangeError              struct{ EntityError }
+	DistinctError               struct{ EntityError }
+	EntityAccessDenied          struct{ EntityError }
+	EntityNotFound              struct{ EntityError }
+	ExtensionSettingError       struct{ EntityError }
+	FeedAttributeReferenceError struct{ EntityError }
+	FeedError                   struct{ EntityError }
+	FeedItemError               struct{ EntityError }
+	FeedMappingError            struct{ EntityError }
+	FieldPathError              struct{ EntityError }
+	ForwardCompatibilityError   struct{ EntityError }
+	FunctionError               struct{ EntityError }
+	FunctionParsingError        struct{ EntityError }
+	IdError                     struct{ EntityError }
+	ImageError                  struct{ EntityError }
+	InternalApiError            struct{ EntityError }
+	ManagedCustomerServiceError struct{ EntityError }
+	MediaBundleError            struct{ EntityError }
+	MediaError                  struct{ EntityError }
+	MultiplierError             struct{ EntityError }
+	NewEntityCreationError      struct{ EntityError }
+	NullError                   struct{ EntityError }
+	OfflineConversionFeedError  struct{ EntityError }
+	OperationAccessDenied       struct{ EntityError }
+	OperatorError               struct{ EntityError }
+	PagingError                 struct{ EntityError }
+	PolicyFindingError          struct{ EntityError }
+	QueryError                  struct{ EntityError }
+	QuotaCheckError             struct{ EntityError }
+	RangeError                  struct{ EntityError }
+	ReadOnlyError               struct{ EntityError }
+	RegionCodeError             struct{ EntityError }
+	RejectedError               struct{ EntityError }
+	ReportDefinitionError       struct{ EntityError }
+	RequestError                struct{ EntityError }
+	RequiredError               struct{ EntityError }
+	SelectorError               struct{ EntityError }
+	SettingError                struct{ EntityError }
+	SharedCriterionError        struct{ EntityError }
+	SharedSetError              struct{ EntityError }
+	SizeLimitError              struct{ EntityError }
+	StatsQueryError             struct{ EntityError }
+	StringFormatError           struct{ EntityError }
+	StringLengthError           struct{ EntityError }
+	TargetingIdeaError          struct{ EntityError }
+	TrafficEstimatorError       struct{ EntityError }
+	UrlError                    struct{ EntityError }
+	UserListError               struct{ EntityError }
+	VideoError                  struct{ EntityError }
+)
+
+// TargetError, AdGroupServiceError, NotEmptyError, AdError, LabelError and
+// RateExceededError predate EntityError, they keep their own fields so
+// that literals of them still compile and behave like EntityError.
+type TargetError struct {
+	FieldPath   string `xml:"fieldPath"`
+	Trigger     string `xml:"trigger"`
+	ErrorString string `xml:"errorString"`
+	Reason      string `xml:"reason"`
+}
+
+type AdGroupServiceError struct {
+	FieldPath   string `xml:"fieldPath"`
+	Trigger     string `xml:"trigger"`
+	ErrorString string `xml:"errorString"`
+	Reason      string `xml:"reason"`
+}
+
+type NotEmptyError struct {
+	FieldPath   string `xml:"fieldPath"`
+	Trigger     string `xml:"trigger"`
+	ErrorString string `xml:"errorString"`
+	Reason      string `xml:"reason"`
+}
+
+type AdError struct {
+	FieldPath   string `xml:"fieldPath"`
+	Trigger     string `xml:"trigger"`
+	ErrorString string `xml:"errorString"`
+	Reason      string `xml:"reason"`
+}
+
+type LabelError struct {
+	FieldPath   string `xml:"fieldPath"`
+	Trigger     string `xml:"trigger"`
+	ErrorString string `xml:"errorString"`
+	Reason      string `xml:"reason"`
+}
+
+// RateExceededError is returned if you exceed the quota given by google.
+type RateExceededError struct {
+	RateName          string `xml:"rateName"`  // For example OperationsByMinute
+	RateScope         string `xml:"rateScope"` // ACCOUNT or DEVELOPER
+	ErrorString       string `xml:"errorString"`
+	Reason            string `xml:"reason"`
+	RetryAfterSeconds uint   `xml:"retryAfterSeconds"` // Try again in...
+}
+
+func (e TargetError) entityError() EntityError {
+	return EntityError{e.FieldPath, e.Trigger, e.ErrorString, e.Reason, "TargetError"}
+}
+
+func (e AdGroupServiceError) entityError() EntityError {
+	return EntityError{e.FieldPath, e.Trigger, e.ErrorString, e.Reason, "AdGroupServiceError"}
+}
+
+func (e NotEmptyError) entityError() EntityError {
+	return EntityError{e.FieldPath, e.Trigger, e.ErrorString, e.Reason, "NotEmptyError"}
+}
+
+func (e AdError) entityError() EntityError {
+	return EntityError{e.FieldPath, e.Trigger, e.ErrorString, e.Reason, "AdError"}
+}
+
+func (e LabelError) entityError() EntityError {
+	return EntityError{e.FieldPath, e.Trigger, e.ErrorString, e.Reason, "LabelError"}
+}
+
+func (e RateExceededError) entityError() EntityError {
+	return EntityError{ErrorString: e.ErrorString, ApiErrorType: "RateExceededError", Reason: e.Reason}
+}
+
+func (e TargetError) Error() string                { return e.entityError().Error() }
+func (e TargetError) ErrorType() string            { return "TargetError" }
+func (e TargetError) ErrorReason() string          { return e.Reason }
+func (e TargetError) fieldPath() string            { return e.FieldPath }
+func (e TargetError) Is(target error) bool         { return e.entityError().Is(target) }
+func (e AdGroupServiceError) Error() string        { return e.entityError().Error() }
+func (e AdGroupServiceError) ErrorType() string    { return "AdGroupServiceError" }
+func (e AdGroupServiceError) ErrorReason() string  { return e.Reason }
+func (e AdGroupServiceError) fieldPath() string    { return e.FieldPath }
+func (e AdGroupServiceError) Is(target error) bool { return e.entityError().Is(target) }
+func (e NotEmptyError) Error() string              { return e.entityError().Error() }
+func (e NotEmptyError) ErrorType() string          { return "NotEmptyError" }
+func (e NotEmptyError) ErrorReason() string        { return e.Reason }
+func (e NotEmptyError) fieldPath() string          { return e.FieldPath }
+func (e NotEmptyError) Is(target error) bool       { return e.entityError().Is(target) }
+func (e AdError) Error() string                    { return e.entityError().Error() }
+func (e AdError) ErrorType() string                { return "AdError" }
+func (e AdError) ErrorReason() string              { return e.Reason }
+func (e AdError) fieldPath() string                { return e.FieldPath }
+func (e AdError) Is(target error) bool             { return e.entityError().Is(target) }
+func (e LabelError) Error() string                 { return e.entityError().Error() }
+func (e LabelError) ErrorType() string             { return "LabelError" }
+func (e LabelError) ErrorReason() string           { return e.Reason }
+func (e LabelError) fieldPath() string             { return e.FieldPath }
+func (e LabelError) Is(target error) bool          { return e.entityError().Is(target) }
+func (e RateExceededError) Error() string          { return e.entityError().Error() }
+func (e RateExceededError) ErrorType() string      { return "RateExceededError" }
+func (e RateExceededError) ErrorReason() string    { return e.Reason }
+func (e RateExceededError) Is(target error) bool   { return e.entityError().Is(target) }
+
+// EntityCountLimitExceeded signals that adding an entity would exceed a
+// limit of the account or of its enclosing entity.
+type EntityCountLimitExceeded struct {
+	EntityError
+	EnclosingId      string `xml:"enclosingId"`
+	Limit            int    `xml:"limit"`
+	AccountLimitType string `xml:"accountLimitType"`
+	ExistingCount    int    `xml:"existingCount"`
+}
+
+type AdGroupAdCountLimitExceeded struct {
+	EntityCountLimitExceeded
+}
+
+type AdGroupCriterionLimitExceeded struct {
+	EntityError
+	LimitType string `xml:"limitType"`
+}
+
+type CampaignCriterionLimitExceeded struct {
+	EntityError
+	LimitType string `xml:"limitType"`
+}
+
+// PolicyViolationKey identifies a policy violation, it is what an
+// exemption request is made for.
+type PolicyViolationKey struct {
+	PolicyName    string `xml:"policyName"`
+	ViolatingText string `xml:"violatingText"`
+}
+
+// PolicyViolationPart locates the violating text within the field.
+type PolicyViolationPart struct {
+	Index  int `xml:"index"`
+	Length int `xml:"length"`
+}
+
+// PolicyViolationError is returned when an ad or keyword violates a policy,
+// if IsExemptable the operation may be sent again with an exemption request
+// for Key.
+type PolicyViolationError struct {
+	EntityError
+	Key                       PolicyViolationKey    `xml:"key"`
+	ExternalPolicyName        string                `xml:"externalPolicyName"`
+	ExternalPolicyUrl         string                `xml:"externalPolicyUrl"`
+	ExternalPolicyDescription string                `xml:"externalPolicyDescription"`
+	IsExemptable              bool                  `xml:"isExemptable"`
+	ViolatingParts            []PolicyViolationPart `xml:"violatingParts"`
+}
+
+// faultErrorTypes maps xsi:types to the types they are decoded into.
+var faultErrorTypes = map[string]reflect.Type{}
+
+func init() {
+	for _, e := range []FaultError{
+		AdCustomizerError{},
+		AdError{},
+		AdGroupAdError{},
+		AdGroupCriterionError{},
+		AdGroupFeedError{},
+		AdGroupServiceError{},
+		AdParamError{},
+		AdSharingError{},
+		AdxError{},
+		AssetError{},
+		AssetLinkError{},
+		AuthenticationError{},
+		AuthorizationError{},
+		BatchJobError{},
+		BetaError{},
+		BiddingError{},
+		BiddingErrors{},
+		BiddingStrategyError{},
+		BudgetError{},
+		CampaignCriterionError{},
+		CampaignError{},
+		CampaignFeedError{},
+		CampaignPreferenceError{},
+		CampaignSharedSetError{},
+		ClientTermsError{},
+		CollectionSizeError{},
+		ConversionTrackingError{},
+		CriterionError{},
+		CurrencyCodeError{},
+		CustomerError{},
+		CustomerFeedError{},
+		CustomerSyncError{},
+		DatabaseError{},
+		DateError{},
+		DateRangeError{},
+		DistinctError{},
+		EntityAccessDenied{},
+		EntityNotFound{},
+		ExtensionSettingError{},
+		FeedAttributeReferenceError{},
+		FeedError{},
+		FeedItemError{},
+		FeedMappingError{},
+		FieldPathError{},
+		ForwardCompatibilityError{},
+		FunctionError{},
+		FunctionParsingError{},
+		IdError{},
+		ImageError{},
+		InternalApiError{},
+		LabelError{},
+		ManagedCustomerServiceError{},
+		MediaBundleError{},
+		MediaError{},
+		MultiplierError{},
+		NewEntityCreationError{},
+		NotEmptyError{},
+		NullError{},
+		OfflineConversionFeedError{},
+		OperationAccessDenied{},
+		OperatorError{},
+		PagingError{},
+		PolicyFindingError{},
+		QueryError{},
+		QuotaCheckError{},
+		RangeError{},
+		ReadOnlyError{},
+		RegionCodeError{},
+		RejectedError{},
+		ReportDefinitionError{},
+		RequestError{},
+		RequiredError{},
+		SelectorError{},
+		SettingError{},
+		SharedCriterionError{},
+		SharedSetError{},
+		SizeLimitError{},
+		StatsQueryError{},
+		StringFormatError{},
+		StringLengthError{},
+		TargetError{},
+		TargetingIdeaError{},
+		TrafficEstimatorError{},
+		UrlError{},
+		UserListError{},
+		VideoError{},
+		RateExceededError{},
+		EntityCountLimitExceeded{},
+		AdGroupAdCountLimitExceeded{},
+		AdGroupCriterionLimitExceeded{},
+		CampaignCriterionLimitExceeded{},
+		PolicyViolationError{},
+	} {
+		t := reflect.TypeOf(e)
+		faultErrorTypes[t.Name()] = t
+	}
+}
@@ -3,12 +3,15 @@ package v201809
 import (
 	"encoding/xml"
 	"fmt"
+	"reflect"
+	"strconv"
 	"strings"
 )
 
 type baseError struct {
-	code    string
-	origErr error
+	code      string
+	origErr   error
+	requestId string
 }
 
 func (b baseError) Error() string {
@@ -27,6 +30,14 @@ func (b baseError) OrigErr() error {
 	return b.origErr
 }
 
+func (b baseError) Unwrap() error {
+	return b.origErr
+}
+
+func (b baseError) RequestId() string {
+	return b.requestId
+}
+
 type Error interface {
 	// Satisfy the generic error interface.
 	error
@@ -45,67 +56,81 @@ type OperationError struct {
 	Message   string `xml:"OperationError>Message"`
 }
 
-type EntityError struct {
-	FieldPath   string `xml:"fieldPath"`
-	Trigger     string `xml:"trigger"`
-	ErrorString string `xml:"errorString"`
-	Reason      string `xml:"reason"`
-}
-
-type BudgetError struct {
-	EntityError
-}
+// FaultError is implemented by EntityError and the error types embedding
+// it, i.e. by every error decoded from the errors of an ApiExceptionFault.
+// Use errors.As to branch on the type of error
+//
+//   var budgetErr gads.BudgetError
+//   if errors.As(err, &budgetErr) && budgetErr.Reason == "DUPLICATE_NAME" {
+//     ...
+//   }
+//
+// or errors.Is to match a type and reason
+//
+//   if errors.Is(err, gads.EntityError{ApiErrorType: "BudgetError", Reason: "DUPLICATE_NAME"}) {
+//     ...
+//   }
+//
+type FaultError interface {
+	error
 
-type CriterionError struct {
-	EntityError
-}
+	// ErrorType returns the xsi:type of the error, e.g. BudgetError.
+	ErrorType() string
 
-type TargetError struct {
-	FieldPath   string `xml:"fieldPath"`
-	Trigger     string `xml:"trigger"`
-	ErrorString string `xml:"errorString"`
-	Reason      string `xml:"reason"`
+	// ErrorReason returns the reason of the error, e.g. DUPLICATE_NAME.
+	ErrorReason() string
 }
 
-type AdGroupServiceError struct {
-	FieldPath   string `xml:"fieldPath"`
-	Trigger     string `xml:"trigger"`
-	ErrorString string `xml:"errorString"`
-	Reason      string `xml:"reason"`
+// EntityError holds the fields common to all ApiErrors, errors of a type
+// this package does not know are decoded into it.
+type EntityError struct {
+	FieldPath    string `xml:"fieldPath"`
+	Trigger      string `xml:"trigger"`
+	ErrorString  string `xml:"errorString"`
+	Reason       string `xml:"reason"`
+	ApiErrorType string `xml:"ApiError.Type"`
 }
 
-type NotEmptyError struct {
-	FieldPath   string `xml:"fieldPath"`
-	Trigger     string `xml:"trigger"`
-	ErrorString string `xml:"errorString"`
-	Reason      string `xml:"reason"`
+func (e EntityError) Error() string {
+	msg := e.ErrorString
+	if msg == "" {
+		msg = e.ApiErrorType + "." + e.Reason
+	}
+	if e.FieldPath != "" {
+		msg = e.FieldPath + ": " + msg
+	}
+	if e.Trigger != "" {
+		msg += " (trigger " + strconv.Quote(e.Trigger) + ")"
+	}
+	return msg
 }
 
-type AdError struct {
-	FieldPath   string `xml:"fieldPath"`
-	Trigger     string `xml:"trigger"`
-	ErrorString string `xml:"errorString"`
-	Reason      string `xml:"reason"`
+func (e EntityError) ErrorType() string {
+	return e.ApiErrorType
 }
 
-type LabelError struct {
-	FieldPath   string `xml:"fieldPath"`
-	Trigger     string `xml:"trigger"`
-	ErrorString string `xml:"errorString"`
-	Reason      string `xml:"reason"`
+func (e EntityError) ErrorReason() string {
+	return e.Reason
 }
 
-type UrlError struct {
-	EntityError
+func (e EntityError) fieldPath() string {
+	return e.FieldPath
 }
 
-// if you exceed the quota given by google
-type RateExceededError struct {
-	RateName          string `xml:"rateName"`  // For example OperationsByMinute
-	RateScope         string `xml:"rateScope"` // ACCOUNT or DEVELOPER
-	ErrorString       string `xml:"errorString"`
-	Reason            string `xml:"reason"`
-	RetryAfterSeconds uint   `xml:"retryAfterSeconds"` // Try again in...
+// Is reports whether target is a FaultError of the same type and reason.
+// An empty reason in target matches any reason and an empty ApiErrorType
+// stands for the Go type of target, so BudgetError{} matches every
+// BudgetError.
+func (e EntityError) Is(target error) bool {
+	t, ok := target.(FaultError)
+	if !ok {
+		return false
+	}
+	errorType := t.ErrorType()
+	if errorType == "" {
+		errorType = reflect.Indirect(reflect.ValueOf(target)).Type().Name()
+	}
+	return errorType == e.ApiErrorType && (t.ErrorReason() == "" || t.ErrorReason() == e.Reason)
 }
 
 type ApiExceptionFault struct {
@@ -133,27 +158,14 @@ func (aes *ApiExceptionFault) UnmarshalXML(dec *xml.Decoder, start xml.StartElem
 				errorType, _ := findAttr(start.Attr, xml.Name{Space: "http://www.w3.org/2001/XMLSchema-instance", Local: "type"})
 				aes.ErrorsType = errorType
 
+				e, err := decodeFaultError(dec, start, errorType)
+				if err != nil {
+					return err
+				}
+				aes.Errors = append(aes.Errors, e)
 				switch errorType {
-				case "RateExceededError":
-					e := RateExceededError{}
-					dec.DecodeElement(&e, &start)
-					aes.Errors = append(aes.Errors, e)
-					aes.Reason = e.Reason
-
-				case "AuthenticationError", "DatabaseError", "InternalApiError":
-					e := EntityError{}
-					if err := dec.DecodeElement(&e, &start); err != nil {
-						return fmt.Errorf("Unknown error type -> %s", start)
-					}
-					aes.Errors = append(aes.Errors, e)
-					aes.Reason = e.Reason
-
-				default:
-					e := EntityError{}
-					if err := dec.DecodeElement(&e, &start); err != nil {
-						return fmt.Errorf("Unknown error type -> %s", start)
-					}
-					aes.Errors = append(aes.Errors, e)
+				case "RateExceededError", "AuthenticationError", "DatabaseError", "InternalApiError":
+					aes.Reason = e.ErrorReason()
 				}
 			case "reason":
 				break
@@ -165,8 +177,29 @@ func (aes *ApiExceptionFault) UnmarshalXML(dec *xml.Decoder, start xml.StartElem
 	return err
 }
 
+// decodeFaultError decodes an ApiError of xsi:type errorType into its
+// concrete type.
+func decodeFaultError(dec *xml.Decoder, start xml.StartElement, errorType string) (FaultError, error) {
+	t, ok := faultErrorTypes[errorType]
+	if !ok {
+		t = reflect.TypeOf(EntityError{})
+	}
+	v := reflect.New(t)
+	if err := dec.DecodeElement(v.Interface(), &start); err != nil {
+		return nil, fmt.Errorf("Unknown error type -> %s", start)
+	}
+	// ApiError.Type is optional, the xsi:type is not
+	if f := v.Elem().FieldByName("ApiErrorType"); f.IsValid() && f.String() == "" {
+		f.SetString(errorType)
+	}
+	return v.Elem().Interface().(FaultError), nil
+}
+
 type ErrorsType struct {
 	ApiExceptionFaults []ApiExceptionFault `xml:"ApiExceptionFault"`
+
+	// RequestId of the failed call, Google support asks for it.
+	RequestId string `xml:"-"`
 }
 
 func (f ErrorsType) Error() string {
@@ -177,6 +210,38 @@ func (f ErrorsType) Error() string {
 	return strings.Join(errors, "\n")
 }
 
+// Unwrap returns the errors of all faults so that errors.As and errors.Is
+// can match them.
+func (f ErrorsType) Unwrap() []error {
+	var errs []error
+	for _, aef := range f.ApiExceptionFaults {
+		for _, e := range aef.Errors {
+			if err, ok := e.(error); ok {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
+// ErrorRequestId returns the requestId of the call that failed with err,
+// or "" if the error does not carry one.
+func ErrorRequestId(err error) string {
+	switch e := err.(type) {
+	case interface{ RequestId() string }:
+		return e.RequestId()
+	case *ErrorsType:
+		return e.RequestId
+	case ErrorsType:
+		return e.RequestId
+	case *HTTPError:
+		return e.RequestId
+	case Error:
+		return ErrorRequestId(e.OrigErr())
+	}
+	return ""
+}
+
 type Fault struct {
 	XMLName     xml.Name   `xml:"Fault"`
 	FaultCode   string     `xml:"faultcode"`
@@ -187,3 +252,7 @@ type Fault struct {
 func (f Fault) Error() string {
 	return f.FaultString + " - " + f.Errors.Error()
 }
+
+func (f Fault) Unwrap() error {
+	return f.Errors
+}
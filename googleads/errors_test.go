@@ -0,0 +1,96 @@
+package v201809
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFaultErrorTypes(t *testing.T) {
+	policyViolation := `<key><policyName>pharmacy</policyName><violatingText>cheap pills</violatingText></key><externalPolicyName>Healthcare</externalPolicyName><isExemptable>true</isExemptable><violatingParts><index>0</index><length>5</length></violatingParts>`
+	client := &countingClient{status: 500, body: testFault("PolicyViolationError", "POLICY_ERROR", policyViolation)}
+	auth := &Auth{Client: client, RetryPolicy: NoRetry}
+
+	_, err := NewAdGroupAdService(auth).Mutate(AdGroupAdOperations{"ADD": {TextAd{AdGroupId: 1}}})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	var pve PolicyViolationError
+	if !errors.As(err, &pve) {
+		t.Fatalf("expected a PolicyViolationError in %#v", err)
+	}
+	if pve.Key.PolicyName != "pharmacy" || pve.Key.ViolatingText != "cheap pills" || !pve.IsExemptable {
+		t.Errorf("got %+v", pve)
+	}
+	if len(pve.ViolatingParts) != 1 || pve.ViolatingParts[0].Length != 5 {
+		t.Errorf("got violating parts %+v", pve.ViolatingParts)
+	}
+	if pve.ErrorType() != "PolicyViolationError" || pve.ErrorReason() != "POLICY_ERROR" {
+		t.Errorf("got %s.%s", pve.ErrorType(), pve.ErrorReason())
+	}
+
+	for _, target := range []error{
+		PolicyViolationError{},
+		EntityError{ApiErrorType: "PolicyViolationError", Reason: "POLICY_ERROR"},
+	} {
+		if !errors.Is(err, target) {
+			t.Errorf("expected err to match %#v", target)
+		}
+	}
+	for _, target := range []error{
+		BudgetError{},
+		EntityError{ApiErrorType: "PolicyViolationError", Reason: "OTHER"},
+	} {
+		if errors.Is(err, target) {
+			t.Errorf("expected err not to match %#v", target)
+		}
+	}
+}
+
+func TestFaultErrorUnknownType(t *testing.T) {
+	client := &countingClient{status: 500, body: testFault("BrandNewError", "SOMETHING", "")}
+	auth := &Auth{Client: client, RetryPolicy: NoRetry}
+
+	_, _, err := NewCampaignService(auth).Get(Selector{})
+	var fe FaultError
+	if !errors.As(err, &fe) {
+		t.Fatalf("expected a FaultError in %#v", err)
+	}
+	if _, ok := fe.(EntityError); !ok || fe.ErrorType() != "BrandNewError" || fe.ErrorReason() != "SOMETHING" {
+		t.Errorf("got %#v", fe)
+	}
+
+	var ree RateExceededError
+	if errors.As(err, &ree) {
+		t.Errorf("unexpected RateExceededError %+v", ree)
+	}
+}
+
+func TestFaultErrorFlatTypes(t *testing.T) {
+	client := &countingClient{status: 500, body: testFault("LabelError", "DUPLICATE_NAME", "<fieldPath>operations[0].operand.name</fieldPath>")}
+	auth := &Auth{Client: client, RetryPolicy: NoRetry}
+
+	_, err := NewLabelService(auth).Mutate(LabelOperations{"ADD": {NewTextLabel("label")}})
+	var le LabelError
+	if !errors.As(err, &le) {
+		t.Fatalf("expected a LabelError in %#v", err)
+	}
+	if le != (LabelError{FieldPath: "operations[0].operand.name", ErrorString: "LabelError.DUPLICATE_NAME", Reason: "DUPLICATE_NAME"}) {
+		t.Errorf("got %#v", le)
+	}
+	for _, target := range []error{
+		LabelError{},
+		LabelError{Reason: "DUPLICATE_NAME"},
+		EntityError{ApiErrorType: "LabelError", Reason: "DUPLICATE_NAME"},
+	} {
+		if !errors.Is(err, target) {
+			t.Errorf("expected err to match %#v", target)
+		}
+	}
+	if errors.Is(err, AdError{Reason: "DUPLICATE_NAME"}) {
+		t.Error("expected err not to match an AdError")
+	}
+	if got := (RateExceededError{Reason: "RATE_EXCEEDED"}).Error(); got != "RateExceededError.RATE_EXCEEDED" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -1,6 +1,9 @@
 package v201809
 
-import "encoding/xml"
+import (
+	"context"
+	"encoding/xml"
+)
 
 type FeedService struct {
 	Auth
@@ -11,8 +14,15 @@ func NewFeedService(auth *Auth) *FeedService {
 }
 
 // https://developers.google.com/adwords/api/docs/reference/v201809/FeedService
-func (s *FeedService) Query(query string) (page []Feed, totalCount int64, err error) {
+func (s *FeedService) Query(query string, opts ...CallOption) (page []Feed, totalCount int64, err error) {
+	return s.QueryWithContext(context.Background(), query, opts...)
+}
+
+// QueryWithContext is the same as Query with the addition of a context.
+func (s *FeedService) QueryWithContext(ctx context.Context, query string, opts ...CallOption) (page []Feed, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 	respBody, err := s.Auth.request(
+		ctx,
 		feedServiceUrl,
 		"query",
 		AWQLQuery{
@@ -0,0 +1,228 @@
+package v201809
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// Exchange is a single HTTP exchange with the API as seen by interceptors.
+// Interceptors may change the request fields before calling the next
+// handler and inspect or replace the response fields afterwards.  A
+// StatusCode or ResponseBody replaced after next returned is decoded again,
+// replacing Err.
+type Exchange struct {
+	ServiceUrl ServiceUrl
+	Action     string // SOAP action, "download" for reports, "start", "upload" and "download" for batch jobs
+
+	Method      string
+	Url         string
+	Header      http.Header
+	RequestBody []byte // SOAP envelope, report download form or batch job operations
+
+	StatusCode     int
+	ResponseHeader http.Header
+	ResponseBody   []byte         // raw response, nil for successful responses which are streamed
+	SoapHeader     ResponseHeader // empty for reports and batch jobs
+	Cached         bool           // response was served from the cache
+	Err            error          // decoded fault or transport error
+
+	result        []byte         // SOAP body handed to the service
+	decoded       bool           // the response has been decoded into result and Err
+	decodedStatus int            // StatusCode when decoded
+	decodedFrom   []byte         // ResponseBody when decoded
+	stream        bool           // leave the response body unread in response
+	response      *http.Response // streamed response of a report download
+}
+
+// setDecoded records that the current response of ex has been decoded.
+func (ex *Exchange) setDecoded() {
+	ex.decoded = true
+	ex.decodedStatus = ex.StatusCode
+	ex.decodedFrom = ex.ResponseBody
+}
+
+// undecoded reports whether ex holds a response body which has not been
+// decoded, either because an interceptor answered the exchange itself or
+// because it replaced the response after it was decoded.
+func (ex *Exchange) undecoded() bool {
+	if ex.ResponseBody == nil {
+		return false
+	}
+	return !ex.decoded || ex.StatusCode != ex.decodedStatus || !bytes.Equal(ex.ResponseBody, ex.decodedFrom)
+}
+
+// Handler sends an exchange and fills in its response.
+type Handler func(ctx context.Context, ex *Exchange) error
+
+// Interceptor wraps every exchange made through an Auth.  It must call next
+// to send the exchange, or fill in StatusCode and ResponseBody itself to
+// answer it without reaching the API.
+//
+// Example
+//
+//   auth.Interceptors = append(auth.Interceptors,
+//     func(ctx context.Context, ex *gads.Exchange, next gads.Handler) error {
+//       start := time.Now()
+//       err := next(ctx, ex)
+//       metrics.Observe(ex.ServiceUrl.Name, ex.Action, time.Since(start), err)
+//       return err
+//     },
+//   )
+//
+type Interceptor func(ctx context.Context, ex *Exchange, next Handler) error
+
+// intercept runs ex through the interceptors, the first one being the
+// outermost, and finally through handler.
+func (a *Auth) intercept(ctx context.Context, ex *Exchange, handler Handler) error {
+	for i := len(a.Interceptors) - 1; i >= 0; i-- {
+		interceptor, next := a.Interceptors[i], handler
+		handler = func(ctx context.Context, ex *Exchange) error {
+			return interceptor(ctx, ex, next)
+		}
+	}
+	return handler(ctx, ex)
+}
+
+// send is the innermost handler, it performs the HTTP request described by
+// ex using client.
+func send(client HttpClient) Handler {
+	return func(ctx context.Context, ex *Exchange) error {
+		req, err := http.NewRequestWithContext(ctx, ex.Method, ex.Url, bytes.NewReader(ex.RequestBody))
+		if err != nil {
+			return err
+		}
+		for k, vs := range ex.Header {
+			req.Header[k] = append([]string(nil), vs...)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		ex.StatusCode = resp.StatusCode
+		ex.ResponseHeader = resp.Header
+		if ex.stream {
+			ex.response = resp
+			return nil
+		}
+		defer resp.Body.Close()
+
+		reader, err := decodedBody(resp)
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		ex.ResponseBody, err = ioutil.ReadAll(reader)
+		return err
+	}
+}
+
+// decodedBody returns the body of resp, decompressed if need be.  Closing
+// it releases the decompressor, resp.Body is still closed by the caller.
+func decodedBody(resp *http.Response) (io.ReadCloser, error) {
+	if resp.Header.Get("Content-Encoding") == "gzip" {
+		return gzip.NewReader(resp.Body)
+	}
+	return ioutil.NopCloser(resp.Body), nil
+}
+
+// soapHandler answers a SOAP exchange from the cache or the API and decodes
+// the response.
+func (a *Auth) soapHandler(ctx context.Context, ex *Exchange) error {
+	cache, ttl, cacheable := a.cacheTTL(ex)
+	if cacheable {
+		if body, ok := cache.Get(cacheKey(ex)); ok {
+			ex.ResponseBody = body
+			ex.StatusCode = 200
+			ex.Cached = true
+			decodeSoapResponse(ex)
+			return ex.Err
+		}
+	}
+
+	if err := a.waitRateLimit(ctx); err != nil {
+		return err
+	}
+	if err := send(a.Client)(ctx, ex); err != nil {
+		return err
+	}
+	decodeSoapResponse(ex)
+	// only successful responses are worth keeping
+	if cacheable && ex.Err == nil {
+		cache.Set(cacheKey(ex), ex.ResponseBody, ttl)
+	}
+	return ex.Err
+}
+
+// decodeSoapResponse extracts the SOAP header and body of ex's response and
+// turns faults and error statuses into ex.Err.
+func decodeSoapResponse(ex *Exchange) {
+	ex.setDecoded()
+	ex.result, ex.Err = nil, nil
+
+	type soapRespBody struct {
+		Response []byte `xml:",innerxml"`
+	}
+
+	soapResp := struct {
+		XMLName xml.Name       `xml:"http://schemas.xmlsoap.org/soap/envelope/ Envelope"`
+		Header  ResponseHeader `xml:"Header>ResponseHeader"`
+		Body    soapRespBody   `xml:"http://schemas.xmlsoap.org/soap/envelope/ Body"`
+	}{}
+
+	err := xml.Unmarshal(ex.ResponseBody, &soapResp)
+	ex.SoapHeader = soapResp.Header
+	if err != nil {
+		if ex.StatusCode >= 300 {
+			ex.Err = &HTTPError{StatusCode: ex.StatusCode, Body: ex.ResponseBody}
+			return
+		}
+		ex.result, ex.Err = ex.ResponseBody, err
+		return
+	}
+	ex.result = soapResp.Body.Response
+
+	requestId := soapResp.Header.RequestId
+	switch ex.StatusCode {
+	case 400, 401, 403, 405, 500:
+		fault := Fault{}
+		if err := xml.Unmarshal(soapResp.Body.Response, &fault); err != nil {
+			ex.result, ex.Err = ex.ResponseBody, err
+			return
+		}
+		fault.Errors.RequestId = requestId
+
+		for i := range fault.Errors.ApiExceptionFaults {
+			switch fault.Errors.ApiExceptionFaults[i].ErrorsType {
+			case "AuthenticationError", "RateExceededError", "DatabaseError", "InternalApiError":
+				ex.Err = &baseError{
+					code:      fault.Errors.ApiExceptionFaults[i].Reason,
+					origErr:   &fault.Errors,
+					requestId: requestId,
+				}
+				return
+			}
+		}
+
+		if fault.Errors.ApiExceptionFaults == nil {
+			ex.Err = &baseError{
+				code:      fault.FaultCode,
+				origErr:   errors.New(fault.FaultString),
+				requestId: requestId,
+			}
+			return
+		}
+
+		ex.Err = &fault.Errors
+	default:
+		if ex.StatusCode >= 300 {
+			ex.Err = &HTTPError{StatusCode: ex.StatusCode, Body: ex.ResponseBody, RequestId: requestId}
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package v201809
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestInterceptorChain(t *testing.T) {
+	var calls []string
+	var seenErr error
+	var seenHeader string
+	client := &countingClient{status: 500, body: testFault("RequiredError", "REQUIRED", "")}
+	auth := &Auth{
+		Client:      client,
+		RetryPolicy: NoRetry,
+		Interceptors: []Interceptor{
+			func(ctx context.Context, ex *Exchange, next Handler) error {
+				calls = append(calls, "outer "+ex.ServiceUrl.Name+"."+ex.Action)
+				ex.Header.Set("X-Audit", "outer")
+				err := next(ctx, ex)
+				seenErr = ex.Err
+				return err
+			},
+			func(ctx context.Context, ex *Exchange, next Handler) error {
+				err := next(ctx, ex)
+				calls = append(calls, "inner "+strconv.Itoa(ex.StatusCode))
+				return err
+			},
+			func(ctx context.Context, ex *Exchange, next Handler) error {
+				seenHeader = ex.Header.Get("X-Audit")
+				if !strings.Contains(string(ex.RequestBody), "<get ") {
+					t.Errorf("expected the marshalled envelope, got\n%s", ex.RequestBody)
+				}
+				return next(ctx, ex)
+			},
+		},
+	}
+
+	_, _, err := NewCampaignService(auth).Get(Selector{})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	expected := []string{"outer CampaignService.get", "inner 500"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("got %v, expected %v", calls, expected)
+	}
+	if seenErr != err {
+		t.Errorf("expected interceptors to see the decoded fault, got %v", seenErr)
+	}
+	if seenHeader != "outer" {
+		t.Errorf("expected header injected by the outer interceptor, got %q", seenHeader)
+	}
+}
+
+func TestInterceptorAnswersRequest(t *testing.T) {
+	client := &countingClient{}
+	auth := &Auth{
+		Client: client,
+		Interceptors: []Interceptor{
+			func(ctx context.Context, ex *Exchange, next Handler) error {
+				switch ex.ServiceUrl.Name {
+				case "CampaignService":
+					ex.StatusCode = 200
+					ex.ResponseBody = []byte(`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><getResponse xmlns="https://adwords.google.com/api/adwords/cm/v201809"><rval><totalNumEntries>1</totalNumEntries><entries><id>42</id><name>stub</name></entries></rval></getResponse></soap:Body></soap:Envelope>`)
+				case "ReportDownloadService":
+					ex.StatusCode = 200
+					ex.ResponseBody = []byte("Campaign ID,Clicks\n42,7\n")
+				}
+				return nil
+			},
+		},
+	}
+
+	campaigns, total, err := NewCampaignService(auth).Get(Selector{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || len(campaigns) != 1 || campaigns[0].Id != 42 {
+		t.Errorf("got %d %+v", total, campaigns)
+	}
+
+	report, err := NewReportDownloadService(auth).AWQL("SELECT CampaignId, Clicks FROM CAMPAIGN_PERFORMANCE_REPORT", "CSV")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []map[string]string{{"Campaign ID": "42", "Clicks": "7"}}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("got %v, expected %v", report, expected)
+	}
+	if client.calls != 0 {
+		t.Errorf("expected no request to reach the client, got %d", client.calls)
+	}
+}
+
+// gzipClient answers with body gzip-encoded.
+type gzipClient struct {
+	body string
+}
+
+func (c *gzipClient) Do(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: 200,
+		Header:     http.Header{"Content-Encoding": {"gzip"}},
+		Body:       ioutil.NopCloser(bytes.NewReader(gzipped(c.body))),
+	}, nil
+}
+
+func TestInterceptorGzipResponses(t *testing.T) {
+	auth := &Auth{Client: &gzipClient{body: soapGetResponse(`<entries><id>1</id><name>one</name></entries>`)}}
+	cs := NewCampaignService(auth)
+
+	campaigns, _, err := cs.Get(Selector{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(campaigns) != 1 || campaigns[0].Name != "one" {
+		t.Errorf("got %+v", campaigns)
+	}
+
+	var names []string
+	_, err = cs.GetEach(Selector{}, func(c Campaign) error {
+		names = append(names, c.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, []string{"one"}) {
+		t.Errorf("got %q", names)
+	}
+}
+
+func TestInterceptorReplacesResponse(t *testing.T) {
+	client := &countingClient{status: 200, body: soapGetResponse(`<entries><id>1</id><name>one</name></entries>`)}
+	auth := &Auth{
+		Client:      client,
+		RetryPolicy: NoRetry,
+		Interceptors: []Interceptor{
+			func(ctx context.Context, ex *Exchange, next Handler) error {
+				err := next(ctx, ex)
+				ex.StatusCode = 200
+				ex.ResponseBody = []byte(soapGetResponse(`<entries><id>2</id><name>replaced</name></entries>`))
+				return err
+			},
+		},
+	}
+
+	for _, status := range []int{200, 500} {
+		client.status = status
+		if status != 200 {
+			client.body = testFault("InternalApiError", "UNEXPECTED_INTERNAL_API_ERROR", "")
+		}
+		campaigns, _, err := NewCampaignService(auth).Get(Selector{})
+		if err != nil {
+			t.Fatalf("%d: %v", status, err)
+		}
+		if len(campaigns) != 1 || campaigns[0].Name != "replaced" {
+			t.Errorf("%d: expected the replaced response, got %+v", status, campaigns)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package v201809
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+const (
+	// DefaultPageSize is the number of entries iterators fetch per request
+	// when the selector does not set Paging.Limit.
+	DefaultPageSize = 500
+)
+
+// maxPageOffset is the highest startIndex + numberResults the API accepts.
+var maxPageOffset int64 = 100000
+
+// ErrPagingLimit is returned by iterators reaching the 100,000th entry of a
+// query, or of a selector with an Ordering, which cannot be split on ids.
+var ErrPagingLimit = errors.New("gads: cannot page past 100000 entries, filter the selector further")
+
+// pageFunc fetches the page of selector and returns its entries, their
+// number and the total number of entries matching selector or -1 if
+// unknown.
+type pageFunc func(ctx context.Context, selector Selector) (page interface{}, count int, totalCount int64, err error)
+
+// pageResult is the outcome of a pageFunc.
+type pageResult struct {
+	page       interface{}
+	count      int
+	totalCount int64
+	err        error
+}
+
+// pageIterator walks the pages of a selector.  Past 100,000 entries it
+// orders the selector by idField and starts over with the entries whose id
+// is at least the last one seen, skipping those already seen.
+type pageIterator struct {
+	// Workers is the number of pages fetched concurrently once the first
+	// page told the total number of entries.  The pages are still handed
+	// out in order, so the Ordering of the selector holds.  Requests go
+	// through the Auth of the service and share its RateLimiter.
+	Workers int
+
+	ctx      context.Context
+	selector Selector
+	fetch    pageFunc
+	id       func(index int) int64 // id of an entry of the current page
+	page     interface{}           // entries of the current page
+
+	pending   []chan pageResult // pages fetched ahead, in order
+	scheduled int64             // offset of the next page to fetch ahead
+	cancel    context.CancelFunc
+
+	idField    string
+	splittable bool
+	predicates []Predicate // of the selector, without the id range
+
+	pageSize   int64
+	offset     int64 // within the current id range
+	totalCount int64
+	index      int
+	count      int
+	skip       int // entries of the next page already seen
+	lastId     int64
+	ties       int   // entries seen with lastId
+	rangeStart int64 // first id of the current range, -1 before splitting
+	done       bool
+	err        error
+}
+
+// newPageIterator returns an iterator over selector ordered by idField,
+// then by tieFields, unless the selector has an ordering of its own.  The
+// id and tie fields are then selected too, as splitting on ids needs them.
+func newPageIterator(ctx context.Context, selector Selector, idField string, tieFields ...string) *pageIterator {
+	it := &pageIterator{
+		ctx:        ctx,
+		selector:   selector,
+		idField:    idField,
+		predicates: selector.Predicates,
+		pageSize:   DefaultPageSize,
+		totalCount: -1,
+		index:      -1,
+		rangeStart: -1,
+	}
+	if selector.Paging != nil {
+		if selector.Paging.Limit > 0 {
+			it.pageSize = selector.Paging.Limit
+		}
+		it.offset = selector.Paging.Offset
+	}
+	if len(selector.Ordering) == 0 && idField != "" {
+		it.splittable = true
+		it.selector.Ordering = []OrderBy{{Field: idField, SortOrder: "ASCENDING"}}
+		it.selector.Fields = append([]string(nil), selector.Fields...)
+		for _, f := range append([]string{idField}, tieFields...) {
+			if f != idField {
+				it.selector.Ordering = append(it.selector.Ordering, OrderBy{Field: f, SortOrder: "ASCENDING"})
+			}
+			if !hasField(it.selector.Fields, f) {
+				it.selector.Fields = append(it.selector.Fields, f)
+			}
+		}
+	}
+	return it
+}
+
+// Next advances to the next entry, it returns false when there are no more
+// entries or an error occurred, see Err.
+func (it *pageIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	it.index++
+	for it.index >= it.count {
+		if it.done {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		if !it.nextPage() {
+			return false
+		}
+	}
+	if it.splittable {
+		if id := it.id(it.index); id == it.lastId {
+			it.ties++
+		} else {
+			it.lastId, it.ties = id, 1
+		}
+	}
+	return true
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *pageIterator) Err() error {
+	return it.err
+}
+
+// Close stops the iteration and cancels the pages being fetched ahead,
+// waiting for their requests to return.  Iterations left before Next
+// returned false must be closed, Close may be called again after that.
+func (it *pageIterator) Close() {
+	pending := it.pending
+	it.stop()
+	for _, ch := range pending {
+		<-ch
+	}
+	it.done, it.count = true, 0
+}
+
+// TotalCount returns the number of entries matching the selector, or of the
+// current id range once past 100,000 entries, or -1 before the first page.
+func (it *pageIterator) TotalCount() int64 {
+	return it.totalCount
+}
+
+func (it *pageIterator) nextPage() bool {
+	var res pageResult
+	if len(it.pending) > 0 {
+		res = <-it.pending[0]
+		it.pending = it.pending[1:]
+	} else {
+		if it.offset > 0 && it.offset+it.pageSize > maxPageOffset {
+			// a range of a single id cannot be split any further
+			if !it.splittable || it.lastId == it.rangeStart {
+				it.err = ErrPagingLimit
+				return false
+			}
+			it.selector.Predicates = append(append([]Predicate(nil), it.predicates...), Predicate{
+				Field:    it.idField,
+				Operator: "GREATER_THAN_EQUALS",
+				Values:   []string{strconv.FormatInt(it.lastId, 10)},
+			})
+			it.offset, it.skip, it.rangeStart = 0, it.ties, it.lastId
+		}
+		res = it.fetchPage(it.ctx, it.selector, it.offset)
+		it.scheduled = it.offset + it.pageSize
+	}
+	if res.err != nil {
+		it.err = res.err
+		it.stop()
+		return false
+	}
+
+	it.page, it.count, it.totalCount = res.page, res.count, res.totalCount
+	it.offset += int64(res.count)
+	if res.count == 0 || int64(res.count) < it.pageSize || (res.totalCount >= 0 && it.offset >= res.totalCount) {
+		it.done = true
+		it.stop()
+	} else {
+		it.fetchAhead()
+	}
+
+	// entries seen before starting over with the last id
+	it.index = it.skip
+	if it.index > it.count {
+		it.index = it.count
+	}
+	it.skip -= it.index
+	return true
+}
+
+// fetchPage fetches the page of selector at offset.
+func (it *pageIterator) fetchPage(ctx context.Context, selector Selector, offset int64) pageResult {
+	selector.Paging = &Paging{Offset: offset, Limit: it.pageSize}
+	page, count, totalCount, err := it.fetch(ctx, selector)
+	return pageResult{page, count, totalCount, err}
+}
+
+// fetchAhead starts fetching the next pages of the current id range, up to
+// Workers at a time.
+func (it *pageIterator) fetchAhead() {
+	if it.Workers < 2 || it.totalCount < 0 {
+		return
+	}
+	if it.cancel == nil {
+		var ctx context.Context
+		ctx, it.cancel = context.WithCancel(it.ctx)
+		it.ctx = ctx
+	}
+	for len(it.pending) < it.Workers && it.scheduled < it.totalCount && it.scheduled+it.pageSize <= maxPageOffset {
+		ch := make(chan pageResult, 1)
+		go func(ctx context.Context, selector Selector, offset int64) {
+			ch <- it.fetchPage(ctx, selector, offset)
+		}(it.ctx, it.selector, it.scheduled)
+		it.pending = append(it.pending, ch)
+		it.scheduled += it.pageSize
+	}
+}
+
+// stop abandons the pages fetched ahead.
+func (it *pageIterator) stop() {
+	if it.cancel != nil {
+		it.cancel()
+	}
+	it.pending = nil
+}
+
+// queryPage returns a pageFunc fetching the pages of an AWQL query by adding
+// a LIMIT clause to it.
+func queryPage(query string, fetch func(ctx context.Context, query string) (page interface{}, count int, totalCount int64, err error)) pageFunc {
+	return func(ctx context.Context, selector Selector) (interface{}, int, int64, error) {
+		return fetch(ctx, fmt.Sprintf("%s LIMIT %d,%d", query, selector.Paging.Offset, selector.Paging.Limit))
+	}
+}
+
+// entityId returns the int64 field at path of v, e.g. Criterion, Id.
+func entityId(v interface{}, path ...string) int64 {
+	rv := reflect.ValueOf(v)
+	for _, name := range path {
+		for rv.Kind() == reflect.Interface || rv.Kind() == reflect.Ptr {
+			rv = rv.Elem()
+		}
+		if rv.Kind() != reflect.Struct {
+			return 0
+		}
+		rv = rv.FieldByName(name)
+	}
+	if rv.Kind() != reflect.Int64 {
+		return 0
+	}
+	return rv.Int()
+}
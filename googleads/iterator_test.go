@@ -0,0 +1,354 @@
+package v201809
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// pagingClient answers get requests with the page of entries selected by
+// the paging and an Id GREATER_THAN_EQUALS predicate of the request.
+type pagingClient struct {
+	ids   []int64 // ascending
+	entry func(i int) string
+	delay time.Duration
+
+	mu          sync.Mutex
+	requests    []string
+	inFlight    int
+	maxInFlight int
+}
+
+var (
+	startIndexRe    = regexp.MustCompile(`<startIndex[^>]*>(\d+)</startIndex>`)
+	numberResultsRe = regexp.MustCompile(`<numberResults[^>]*>(\d+)</numberResults>`)
+	idRangeRe       = regexp.MustCompile(`<field>Id</field>\s*<operator>GREATER_THAN_EQUALS</operator>\s*<values>(\d+)</values>`)
+)
+
+func (c *pagingClient) Do(req *http.Request) (*http.Response, error) {
+	body, _ := ioutil.ReadAll(req.Body)
+	c.mu.Lock()
+	c.requests = append(c.requests, string(body))
+	c.inFlight++
+	if c.inFlight > c.maxInFlight {
+		c.maxInFlight = c.inFlight
+	}
+	c.mu.Unlock()
+	time.Sleep(c.delay)
+	defer func() {
+		c.mu.Lock()
+		c.inFlight--
+		c.mu.Unlock()
+	}()
+
+	atoi := func(re *regexp.Regexp, dflt int64) int64 {
+		if m := re.FindSubmatch(body); m != nil {
+			n, _ := strconv.ParseInt(string(m[1]), 10, 64)
+			return n
+		}
+		return dflt
+	}
+	offset, limit, minId := atoi(startIndexRe, 0), atoi(numberResultsRe, 0), atoi(idRangeRe, 0)
+
+	matching := []int{}
+	for i, id := range c.ids {
+		if id >= minId {
+			matching = append(matching, i)
+		}
+	}
+	entries := ""
+	for j := offset; j < offset+limit && j < int64(len(matching)); j++ {
+		entries += c.entry(matching[j])
+	}
+	resp := `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><getResponse xmlns="https://adwords.google.com/api/adwords/cm/v201809"><rval><totalNumEntries>` + strconv.Itoa(len(matching)) + `</totalNumEntries>` + entries + `</rval></getResponse></soap:Body></soap:Envelope>`
+	return &http.Response{StatusCode: 200, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewBufferString(resp))}, nil
+}
+
+func TestIterateCampaigns(t *testing.T) {
+	defer func(max int64) { maxPageOffset = max }(maxPageOffset)
+	maxPageOffset = 10
+
+	client := &pagingClient{}
+	for id := int64(1); id <= 25; id++ {
+		client.ids = append(client.ids, id)
+	}
+	client.entry = func(i int) string {
+		return fmt.Sprintf("<entries><id>%d</id></entries>", client.ids[i])
+	}
+	auth := &Auth{Client: client}
+
+	it := NewCampaignService(auth).Iterate(Selector{Fields: []string{"Id"}, Paging: &Paging{Limit: 4}})
+	ids := []int64{}
+	for it.Next() {
+		ids = append(ids, it.Campaign().Id)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(ids) != fmt.Sprint(client.ids) {
+		t.Errorf("got %v", ids)
+	}
+	if !strings.Contains(client.requests[0], "<field>Id</field>") || !strings.Contains(client.requests[0], "ASCENDING") {
+		t.Errorf("expected the selector to be ordered by Id\n%s", client.requests[0])
+	}
+	split := false
+	for _, req := range client.requests {
+		split = split || idRangeRe.MatchString(req)
+	}
+	if !split {
+		t.Error("expected the selector to be split on ids past the offset ceiling")
+	}
+}
+
+func TestIterateSelectsIds(t *testing.T) {
+	defer func(max int64) { maxPageOffset = max }(maxPageOffset)
+	maxPageOffset = 10
+
+	client := &pagingClient{}
+	for id := int64(1); id <= 25; id++ {
+		client.ids = append(client.ids, id)
+	}
+	client.entry = func(i int) string {
+		return fmt.Sprintf("<entries><id>%d</id><name>c%d</name></entries>", client.ids[i], client.ids[i])
+	}
+	selector := Selector{Fields: []string{"Name"}, Paging: &Paging{Limit: 4}}
+	it := NewCampaignService(&Auth{Client: client}).Iterate(selector)
+	n := 0
+	for it.Next() {
+		n++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != len(client.ids) {
+		t.Errorf("got %d campaigns, expected %d", n, len(client.ids))
+	}
+	for _, req := range client.requests {
+		if !strings.Contains(req, "<fields>Id</fields>") {
+			t.Fatalf("expected Id to be selected\n%s", req)
+		}
+	}
+	if len(selector.Fields) != 1 {
+		t.Errorf("the fields of the selector were changed to %q", selector.Fields)
+	}
+
+	client = &pagingClient{ids: []int64{1, 2}}
+	client.entry = func(i int) string {
+		return fmt.Sprintf(`<entries xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="BiddableAdGroupCriterion"><adGroupId>%d</adGroupId><criterion xsi:type="Keyword"><id>%d</id><Criterion.Type>Keyword</Criterion.Type></criterion></entries>`, i, client.ids[i])
+	}
+	criteria := NewAdGroupCriterionService(&Auth{Client: client}).Iterate(Selector{Fields: []string{"KeywordText", "Id"}})
+	for criteria.Next() {
+	}
+	if err := criteria.Err(); err != nil {
+		t.Fatal(err)
+	}
+	fields := regexp.MustCompile(`<fields>(\w+)</fields>`).FindAllStringSubmatch(client.requests[0], -1)
+	if len(fields) != 3 || fields[0][1] != "KeywordText" || fields[1][1] != "Id" || fields[2][1] != "AdGroupId" {
+		t.Errorf("expected AdGroupId to be selected once besides Id\n%s", client.requests[0])
+	}
+}
+
+func TestIterateExactPages(t *testing.T) {
+	client := &pagingClient{ids: []int64{1, 2, 3, 4, 5, 6, 7, 8}}
+	client.entry = func(i int) string {
+		return fmt.Sprintf("<entries><budgetId>%d</budgetId></entries>", client.ids[i])
+	}
+	it := NewBudgetService(&Auth{Client: client}).Iterate(Selector{Paging: &Paging{Limit: 4}})
+	n := 0
+	for it.Next() {
+		n++
+	}
+	if n != 8 || len(client.requests) != 2 {
+		t.Errorf("got %d budgets in %d requests, expected 8 in 2", n, len(client.requests))
+	}
+	if it.TotalCount() != 8 {
+		t.Errorf("got total count %d", it.TotalCount())
+	}
+}
+
+func TestIterateCriteriaSharingIds(t *testing.T) {
+	defer func(max int64) { maxPageOffset = max }(maxPageOffset)
+	maxPageOffset = 8
+
+	// the same keyword in several ad groups has the same id
+	client := &pagingClient{ids: []int64{1, 1, 1, 2, 2, 3, 3, 3, 3, 4, 5, 5}}
+	client.entry = func(i int) string {
+		return fmt.Sprintf(`<entries xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="BiddableAdGroupCriterion"><adGroupId>%d</adGroupId><criterion xsi:type="Keyword"><id>%d</id><Criterion.Type>Keyword</Criterion.Type></criterion></entries>`, i, client.ids[i])
+	}
+	it := NewAdGroupCriterionService(&Auth{Client: client}).Iterate(Selector{Paging: &Paging{Limit: 3}})
+	seen := map[int64]bool{}
+	for it.Next() {
+		c := it.AdGroupCriterion().(BiddableAdGroupCriterion)
+		if seen[c.AdGroupId] {
+			t.Errorf("got ad group %d twice", c.AdGroupId)
+		}
+		seen[c.AdGroupId] = true
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != len(client.ids) {
+		t.Errorf("got %d criteria, expected %d", len(seen), len(client.ids))
+	}
+}
+
+func TestIterateCampaignCriteria(t *testing.T) {
+	defer func(max int64) { maxPageOffset = max }(maxPageOffset)
+	maxPageOffset = 8
+
+	// the same location in several campaigns has the same id
+	client := &pagingClient{ids: []int64{1, 1, 1, 2, 2, 3, 3, 3, 3, 4, 5, 5}}
+	client.entry = func(i int) string {
+		return fmt.Sprintf(`<entries><campaignId>%d</campaignId><criterion xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="Location"><id>%d</id><Criterion.Type>Location</Criterion.Type></criterion></entries>`, i, client.ids[i])
+	}
+	it := NewCampaignCriterionService(&Auth{Client: client}).Iterate(Selector{Fields: []string{"LocationName"}, Paging: &Paging{Limit: 3}})
+	defer it.Close()
+	seen := map[int64]bool{}
+	for it.Next() {
+		c := it.CampaignCriterion().(CampaignCriterion)
+		if seen[c.CampaignId] {
+			t.Errorf("got campaign %d twice", c.CampaignId)
+		}
+		seen[c.CampaignId] = true
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != len(client.ids) {
+		t.Errorf("got %d criteria, expected %d", len(seen), len(client.ids))
+	}
+	if !strings.Contains(client.requests[0], "<field>CampaignId</field>") {
+		t.Errorf("expected the criteria to be ordered by campaign id\n%s", client.requests[0])
+	}
+}
+
+func TestIterateStops(t *testing.T) {
+	client := &pagingClient{ids: []int64{1, 2, 3, 4, 5}}
+	client.entry = func(i int) string {
+		return fmt.Sprintf("<entries><id>%d</id></entries>", client.ids[i])
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	it := NewAdGroupService(&Auth{Client: client}).IterateWithContext(ctx, Selector{Paging: &Paging{Limit: 2}})
+	n := 0
+	for it.Next() {
+		n++
+		cancel()
+	}
+	if n != 2 || !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("expected the iteration to stop after the first page, got %d entries and %v", n, it.Err())
+	}
+
+	faulty := &countingClient{status: 500, body: testFault("EntityNotFound", "INVALID_ID", "")}
+	it = NewAdGroupService(&Auth{Client: faulty, RetryPolicy: NoRetry}).Iterate(Selector{})
+	if it.Next() || !IsNotFound(it.Err()) {
+		t.Errorf("expected the error of the request, got %v", it.Err())
+	}
+}
+
+func TestIterateQuery(t *testing.T) {
+	defer func(max int64) { maxPageOffset = max }(maxPageOffset)
+	maxPageOffset = DefaultPageSize + 100
+
+	entries := strings.Repeat("<entries><id>1</id></entries>", DefaultPageSize)
+	client := &recordingClient{countingClient: countingClient{status: 200, body: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><queryResponse xmlns="https://adwords.google.com/api/adwords/cm/v201809"><rval><totalNumEntries>5000</totalNumEntries>` + entries + `</rval></queryResponse></soap:Body></soap:Envelope>`}}
+	it := NewLabelService(&Auth{Client: client}).IterateQuery("SELECT LabelId FROM Label")
+	n := 0
+	for it.Next() {
+		n++
+	}
+	if n != DefaultPageSize || it.Err() != ErrPagingLimit {
+		t.Errorf("expected a page of labels and ErrPagingLimit, got %d and %v", n, it.Err())
+	}
+	if !strings.Contains(client.bodies[0], "SELECT LabelId FROM Label LIMIT 0,500") {
+		t.Errorf("expected the query to be paged\n%s", client.bodies[0])
+	}
+}
+
+func TestIterateWorkers(t *testing.T) {
+	defer func(max int64) { maxPageOffset = max }(maxPageOffset)
+	maxPageOffset = 20
+
+	client := &pagingClient{delay: 10 * time.Millisecond}
+	for id := int64(1); id <= 50; id++ {
+		client.ids = append(client.ids, id)
+	}
+	client.entry = func(i int) string {
+		return fmt.Sprintf("<entries><id>%d</id></entries>", client.ids[i])
+	}
+	auth := &Auth{Client: client}
+
+	it := NewCampaignService(auth).Iterate(Selector{Fields: []string{"Id"}, Paging: &Paging{Limit: 3}})
+	it.Workers = 4
+	ids := []int64{}
+	for it.Next() {
+		ids = append(ids, it.Campaign().Id)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(ids) != fmt.Sprint(client.ids) {
+		t.Errorf("got %v", ids)
+	}
+	if client.maxInFlight < 2 {
+		t.Errorf("expected pages to be fetched concurrently, got at most %d requests at once", client.maxInFlight)
+	}
+}
+
+func TestIterateWorkersStopsOnError(t *testing.T) {
+	client := &countingClient{status: 500, body: testFault("EntityNotFound", "INVALID_ID", "")}
+	auth := &Auth{Client: client}
+	it := NewCampaignService(auth).Iterate(Selector{Fields: []string{"Id"}})
+	it.Workers = 4
+	if it.Next() {
+		t.Fatal("expected no entries")
+	}
+	if it.Err() == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestIterateClose(t *testing.T) {
+	client := &pagingClient{delay: 10 * time.Millisecond}
+	for id := int64(1); id <= 50; id++ {
+		client.ids = append(client.ids, id)
+	}
+	client.entry = func(i int) string {
+		return fmt.Sprintf("<entries><id>%d</id></entries>", client.ids[i])
+	}
+	auth := &Auth{Client: client}
+
+	it := NewCampaignService(auth).Iterate(Selector{Fields: []string{"Id"}, Paging: &Paging{Limit: 3}})
+	it.Workers = 4
+	if !it.Next() {
+		t.Fatal(it.Err())
+	}
+	it.Close()
+
+	client.mu.Lock()
+	inFlight, requests := client.inFlight, len(client.requests)
+	client.mu.Unlock()
+	if inFlight != 0 {
+		t.Errorf("expected no requests in flight after Close, got %d", inFlight)
+	}
+	if it.Next() {
+		t.Error("expected no entries after Close")
+	}
+	it.Close()
+	time.Sleep(20 * time.Millisecond)
+	if len(client.requests) != requests {
+		t.Errorf("expected no requests after Close, got %d more", len(client.requests)-requests)
+	}
+	if requests > 1+it.Workers {
+		t.Errorf("expected at most %d requests, got %d", 1+it.Workers, requests)
+	}
+}
@@ -1,6 +1,7 @@
 package v201809
 
 import (
+	"context"
 	"encoding/xml"
 )
 
@@ -55,9 +56,16 @@ type LabelOperations map[string][]Label
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201409/LabelService#get
 //
-func (s LabelService) Get(selector Selector) (labels []Label, totalCount int64, err error) {
+func (s LabelService) Get(selector Selector, opts ...CallOption) (labels []Label, totalCount int64, err error) {
+	return s.GetWithContext(context.Background(), selector, opts...)
+}
+
+// GetWithContext is the same as Get with the addition of a context.
+func (s LabelService) GetWithContext(ctx context.Context, selector Selector, opts ...CallOption) (labels []Label, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 	selector.XMLName = xml.Name{baseUrl, "serviceSelector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		labelServiceUrl,
 		"get",
 		struct {
@@ -85,6 +93,52 @@ func (s LabelService) Get(selector Selector) (labels []Label, totalCount int64,
 	return getResp.Labels, getResp.Size, err
 }
 
+// LabelIterator walks the labels of a selector or query, see
+// LabelService.Iterate.
+type LabelIterator struct {
+	*pageIterator
+}
+
+// Label returns the current label.
+func (it *LabelIterator) Label() Label {
+	return it.page.([]Label)[it.index]
+}
+
+// Iterate returns an iterator over all labels matching selector, which
+// fetches them page by page, see CampaignService.Iterate.
+func (s LabelService) Iterate(selector Selector, opts ...CallOption) *LabelIterator {
+	return s.IterateWithContext(context.Background(), selector, opts...)
+}
+
+// IterateWithContext is the same as Iterate with the addition of a context,
+// the iteration stops with the error of the context once it is done.
+func (s LabelService) IterateWithContext(ctx context.Context, selector Selector, opts ...CallOption) *LabelIterator {
+	it := &LabelIterator{pageIterator: newPageIterator(ctx, selector, "LabelId")}
+	it.fetch = func(ctx context.Context, selector Selector) (interface{}, int, int64, error) {
+		labels, totalCount, err := s.GetWithContext(ctx, selector, opts...)
+		return labels, len(labels), totalCount, err
+	}
+	it.id = func(i int) int64 { return it.page.([]Label)[i].Id }
+	return it
+}
+
+// IterateQuery returns an iterator over the labels of an AWQL query without
+// LIMIT clause, see Iterate.  Queries are not split on ids, the iteration
+// stops with ErrPagingLimit past 100,000 entries.
+func (s *LabelService) IterateQuery(query string, opts ...CallOption) *LabelIterator {
+	return s.IterateQueryWithContext(context.Background(), query, opts...)
+}
+
+// IterateQueryWithContext is the same as IterateQuery with the addition of a context.
+func (s *LabelService) IterateQueryWithContext(ctx context.Context, query string, opts ...CallOption) *LabelIterator {
+	it := &LabelIterator{pageIterator: newPageIterator(ctx, Selector{}, "")}
+	it.fetch = queryPage(query, func(ctx context.Context, query string) (interface{}, int, int64, error) {
+		labels, totalCount, err := s.QueryWithContext(ctx, query, opts...)
+		return labels, len(labels), totalCount, err
+	})
+	return it
+}
+
 // Mutate allows you to add, modify and remove labels, returning the
 // modified labels.
 //
@@ -109,7 +163,13 @@ func (s LabelService) Get(selector Selector) (labels []Label, totalCount int64,
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201409/LabelService#mutate
 //
-func (s *LabelService) Mutate(labelOperations LabelOperations) (labels []Label, err error) {
+func (s *LabelService) Mutate(labelOperations LabelOperations, opts ...CallOption) (labels []Label, err error) {
+	return s.MutateWithContext(context.Background(), labelOperations, opts...)
+}
+
+// MutateWithContext is the same as Mutate with the addition of a context.
+func (s *LabelService) MutateWithContext(ctx context.Context, labelOperations LabelOperations, opts ...CallOption) (labels []Label, err error) {
+	ctx = withCallOptions(ctx, opts)
 	type labelOperation struct {
 		Action string `xml:"operator"`
 		Label  Label  `xml:"operand"`
@@ -135,18 +195,19 @@ func (s *LabelService) Mutate(labelOperations LabelOperations) (labels []Label,
 		},
 		Ops: operations,
 	}
-	respBody, err := s.Auth.request(labelServiceUrl, "mutate", mutation)
+	respBody, err := s.Auth.request(ctx, labelServiceUrl, "mutate", mutation)
 	if err != nil {
 		return labels, err
 	}
 	mutateResp := struct {
-		Labels []Label `xml:"rval>value"`
+		Labels               []Label              `xml:"rval>value"`
+		PartialFailureErrors partialFailureErrors `xml:"rval>partialFailureErrors"`
 	}{}
 	err = xml.Unmarshal([]byte(respBody), &mutateResp)
 	if err != nil {
 		return labels, err
 	}
-	return mutateResp.Labels, err
+	return mutateResp.Labels, partialFailure(operations, mutateResp.PartialFailureErrors)
 }
 
 // Query is not yet implemented
@@ -155,9 +216,16 @@ func (s *LabelService) Mutate(labelOperations LabelOperations) (labels []Label,
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201506/LabelService#query
 //
-func (s *LabelService) Query(query string) (labels []Label, totalCount int64, err error) {
+func (s *LabelService) Query(query string, opts ...CallOption) (labels []Label, totalCount int64, err error) {
+	return s.QueryWithContext(context.Background(), query, opts...)
+}
+
+// QueryWithContext is the same as Query with the addition of a context.
+func (s *LabelService) QueryWithContext(ctx context.Context, query string, opts ...CallOption) (labels []Label, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 
 	respBody, err := s.Auth.request(
+		ctx,
 		labelServiceUrl,
 		"query",
 		AWQLQuery{
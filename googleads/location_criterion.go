@@ -1,6 +1,7 @@
 package v201809
 
 import (
+	"context"
 	"encoding/xml"
 )
 
@@ -22,9 +23,16 @@ type LocationCriterion struct {
 
 type LocationCriterions []LocationCriterion
 
-func (s *LocationCriterionService) Get(selector Selector) (locationCriterions LocationCriterions, err error) {
+func (s *LocationCriterionService) Get(selector Selector, opts ...CallOption) (locationCriterions LocationCriterions, err error) {
+	return s.GetWithContext(context.Background(), selector, opts...)
+}
+
+// GetWithContext is the same as Get with the addition of a context.
+func (s *LocationCriterionService) GetWithContext(ctx context.Context, selector Selector, opts ...CallOption) (locationCriterions LocationCriterions, err error) {
+	ctx = withCallOptions(ctx, opts)
 	selector.XMLName = xml.Name{baseUrl, "selector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		locationCriterionServiceUrl,
 		"get",
 		struct {
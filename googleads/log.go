@@ -0,0 +1,146 @@
+package v201809
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"time"
+)
+
+// LogEvent describes a single exchange with the API.
+type LogEvent struct {
+	Service   string        // e.g. CampaignService, ReportDownloadService
+	Action    string        // SOAP action, e.g. get, mutate
+	Url       string        // url the request was sent to
+	Duration  time.Duration // time until the response was read
+	Status    int           // HTTP status, 0 when no response was received
+	RequestId string        // requestId of the response header, when known
+	Cached    bool          // response came from the cache
+	Err       error         // error returned to the caller
+
+	// Headers and bodies are only set when Auth.LogBodies is true and are
+	// redacted with RedactHeader and RedactBody.
+	RequestHeader http.Header
+	RequestBody   []byte
+	ResponseBody  []byte
+}
+
+// Logger receives an event for every request made through an Auth.
+type Logger interface {
+	Log(event LogEvent)
+}
+
+// LoggerFunc adapts a function to the Logger interface.
+type LoggerFunc func(event LogEvent)
+
+func (f LoggerFunc) Log(event LogEvent) {
+	f(event)
+}
+
+// debugLogger prints events and bodies to stdout, it stands in for a
+// missing Logger when the DEBUG environment variable is set.
+var debugLogger = LoggerFunc(func(e LogEvent) {
+	fmt.Printf("%s %s %s status=%d requestId=%s duration=%s cached=%t err=%v\n",
+		e.Service, e.Action, e.Url, e.Status, e.RequestId, e.Duration, e.Cached, e.Err)
+	if e.RequestBody != nil {
+		fmt.Printf("request ->\n%#v\n%s\n", e.RequestHeader, e.RequestBody)
+	}
+	if e.ResponseBody != nil {
+		fmt.Printf("response ->\n%s\n", e.ResponseBody)
+	}
+})
+
+var (
+	redactedValue = "REDACTED"
+
+	// elements holding secrets or personal data, members are the user list
+	// entries of AdwordsUserListService.mutateMembers
+	redactElements = regexp.MustCompile(`(?s)(<(?:[\w-]+:)?(?:developerToken|members)\b[^>/]*>).*?(</(?:[\w-]+:)?(?:developerToken|members)>)`)
+	bearerToken    = regexp.MustCompile(`(?i)^(bearer|oauth)\s+.+$`)
+)
+
+// RedactBody returns a copy of a SOAP or report download body with the
+// developer token and user list members replaced.
+func RedactBody(body []byte) []byte {
+	if body == nil {
+		return nil
+	}
+	return redactElements.ReplaceAll(body, []byte("${1}"+redactedValue+"${2}"))
+}
+
+// RedactHeader returns a copy of h with the developer token and OAuth
+// credentials replaced.
+func RedactHeader(h http.Header) http.Header {
+	redacted := http.Header{}
+	for k, vs := range h {
+		for _, v := range vs {
+			switch http.CanonicalHeaderKey(k) {
+			case "Developertoken", "Authorization":
+				if m := bearerToken.FindStringSubmatch(v); m != nil {
+					v = m[1] + " " + redactedValue
+				} else {
+					v = redactedValue
+				}
+			}
+			redacted.Add(k, v)
+		}
+	}
+	return redacted
+}
+
+// redactUrl drops the query of u, temporary urls are signed there.
+func redactUrl(u *url.URL) string {
+	redacted := *u
+	redacted.RawQuery = ""
+	return redacted.String()
+}
+
+func (a *Auth) logger() Logger {
+	if a.Logger != nil {
+		return a.Logger
+	}
+	if os.Getenv("DEBUG") != "" {
+		return debugLogger
+	}
+	return nil
+}
+
+// logBodies reports whether headers and bodies are passed to the logger.
+func (a *Auth) logBodies() bool {
+	return a.LogBodies || (a.Logger == nil && os.Getenv("DEBUG") != "")
+}
+
+// logExchange logs ex once it has been answered or failed with err.
+func (a *Auth) logExchange(ex *Exchange, duration time.Duration, err error) {
+	event := LogEvent{
+		Service:   ex.ServiceUrl.Name,
+		Action:    ex.Action,
+		Url:       ex.Url,
+		Duration:  duration,
+		Status:    ex.StatusCode,
+		RequestId: ex.SoapHeader.RequestId,
+		Cached:    ex.Cached,
+		Err:       err,
+	}
+	if u, err := url.Parse(ex.Url); err == nil {
+		event.Url = redactUrl(u)
+	}
+	a.log(event, ex.Header, ex.RequestBody, ex.ResponseBody)
+}
+
+// log sends event to the configured logger, attaching redacted copies of
+// the exchange when bodies are logged.
+func (a *Auth) log(event LogEvent, reqHeader http.Header, reqBody, respBody []byte) {
+	logger := a.logger()
+	if logger == nil {
+		return
+	}
+	if a.logBodies() {
+		event.RequestHeader = RedactHeader(reqHeader)
+		event.RequestBody = RedactBody(reqBody)
+		event.ResponseBody = RedactBody(respBody)
+	}
+	logger.Log(event)
+}
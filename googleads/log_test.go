@@ -0,0 +1,67 @@
+package v201809
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	body := []byte(`<Envelope><Header><RequestHeader xmlns="https://adwords.google.com/api/adwords/rm/v201809"><developerToken>secret-token</developerToken></RequestHeader></Header><Body><mutateMembers><operations><operand><userListId>1</userListId><members>a@example.com</members><members>b@example.com</members></operand></operations></mutateMembers></Body></Envelope>`)
+
+	redacted := string(RedactBody(body))
+	for _, secret := range []string{"secret-token", "a@example.com", "b@example.com"} {
+		if strings.Contains(redacted, secret) {
+			t.Errorf("%q not redacted from\n%s", secret, redacted)
+		}
+	}
+	if !strings.Contains(redacted, "<userListId>1</userListId>") {
+		t.Errorf("expected other elements to be kept\n%s", redacted)
+	}
+}
+
+func TestRedactHeader(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer ya29.secret")
+	h.Set("developerToken", "secret-token")
+	h.Set("clientCustomerId", "123-456-7890")
+
+	redacted := RedactHeader(h)
+	if got := redacted.Get("Authorization"); got != "Bearer REDACTED" {
+		t.Errorf("got Authorization %q", got)
+	}
+	if got := redacted.Get("developerToken"); got != "REDACTED" {
+		t.Errorf("got developerToken %q", got)
+	}
+	if got := redacted.Get("clientCustomerId"); got != "123-456-7890" {
+		t.Errorf("got clientCustomerId %q", got)
+	}
+	if h.Get("developerToken") != "secret-token" {
+		t.Errorf("expected the original header to be unchanged")
+	}
+}
+
+func TestLoggerEvents(t *testing.T) {
+	var events []LogEvent
+	client := &countingClient{status: 200, body: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Header><ResponseHeader xmlns="https://adwords.google.com/api/adwords/cm/v201809"><requestId>0005a1b2c3</requestId><serviceName>CampaignService</serviceName><methodName>get</methodName><operations>1</operations><responseTime>42</responseTime></ResponseHeader></soap:Header><soap:Body><getResponse xmlns="https://adwords.google.com/api/adwords/cm/v201809"><rval><totalNumEntries>0</totalNumEntries></rval></getResponse></soap:Body></soap:Envelope>`}
+	auth := &Auth{
+		DeveloperToken: "secret-token",
+		Client:         client,
+		Logger:         LoggerFunc(func(e LogEvent) { events = append(events, e) }),
+		LogBodies:      true,
+	}
+
+	if _, _, err := NewCampaignService(auth).Get(Selector{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("got %d events, expected 1", len(events))
+	}
+	e := events[0]
+	if e.Service != "CampaignService" || e.Action != "get" || e.Status != 200 || e.RequestId != "0005a1b2c3" {
+		t.Errorf("unexpected event %+v", e)
+	}
+	if strings.Contains(string(e.RequestBody), "secret-token") {
+		t.Errorf("developer token not redacted from\n%s", e.RequestBody)
+	}
+}
@@ -1,7 +1,11 @@
 package v201809
 
 import (
+	"context"
 	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
 )
 
 type ManagedCustomer struct {
@@ -44,9 +48,16 @@ func NewManagedCustomerService(auth *Auth) *ManagedCustomerService {
 	return &ManagedCustomerService{Auth: *auth}
 }
 
-func (s *ManagedCustomerService) Get(selector Selector) (managedCustomerPage ManagedCustomerPage, totalCount int64, err error) {
+func (s *ManagedCustomerService) Get(selector Selector, opts ...CallOption) (managedCustomerPage ManagedCustomerPage, totalCount int64, err error) {
+	return s.GetWithContext(context.Background(), selector, opts...)
+}
+
+// GetWithContext is the same as Get with the addition of a context.
+func (s *ManagedCustomerService) GetWithContext(ctx context.Context, selector Selector, opts ...CallOption) (managedCustomerPage ManagedCustomerPage, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 	selector.XMLName = xml.Name{baseMcmUrl, "serviceSelector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		managedCustomerServiceUrl,
 		"get",
 		struct {
@@ -68,10 +79,45 @@ func (s *ManagedCustomerService) Get(selector Selector) (managedCustomerPage Man
 	if err != nil {
 		return managedCustomerPage, totalCount, err
 	}
-	return getResp, totalCount, nil
+	return getResp, getResp.Size, nil
+}
+
+// ManagedCustomerIterator walks the managed customers of a selector, see
+// ManagedCustomerService.Iterate.
+type ManagedCustomerIterator struct {
+	*pageIterator
+}
+
+// ManagedCustomer returns the current managed customer.
+func (it *ManagedCustomerIterator) ManagedCustomer() ManagedCustomer {
+	return it.page.([]ManagedCustomer)[it.index]
+}
+
+// Iterate returns an iterator over all managed customers matching selector, which
+// fetches them page by page, see CampaignService.Iterate.
+func (s *ManagedCustomerService) Iterate(selector Selector, opts ...CallOption) *ManagedCustomerIterator {
+	return s.IterateWithContext(context.Background(), selector, opts...)
 }
 
-func (s *ManagedCustomerService) Mutate(managedCustomerOperations ManagedCustomerOperations) (managedCustomers []ManagedCustomer, err error) {
+// IterateWithContext is the same as Iterate with the addition of a context,
+// the iteration stops with the error of the context once it is done.
+func (s *ManagedCustomerService) IterateWithContext(ctx context.Context, selector Selector, opts ...CallOption) *ManagedCustomerIterator {
+	it := &ManagedCustomerIterator{pageIterator: newPageIterator(ctx, selector, "CustomerId")}
+	it.fetch = func(ctx context.Context, selector Selector) (interface{}, int, int64, error) {
+		page, totalCount, err := s.GetWithContext(ctx, selector, opts...)
+		return page.ManagedCustomers, len(page.ManagedCustomers), totalCount, err
+	}
+	it.id = func(i int) int64 { return it.page.([]ManagedCustomer)[i].CustomerId }
+	return it
+}
+
+func (s *ManagedCustomerService) Mutate(managedCustomerOperations ManagedCustomerOperations, opts ...CallOption) (managedCustomers []ManagedCustomer, err error) {
+	return s.MutateWithContext(context.Background(), managedCustomerOperations, opts...)
+}
+
+// MutateWithContext is the same as Mutate with the addition of a context.
+func (s *ManagedCustomerService) MutateWithContext(ctx context.Context, managedCustomerOperations ManagedCustomerOperations, opts ...CallOption) (managedCustomers []ManagedCustomer, err error) {
+	ctx = withCallOptions(ctx, opts)
 	type managedCustomerOperation struct {
 		Action          string          `xml:"https://adwords.google.com/api/adwords/cm/v201809 operator"`
 		ManagedCustomer ManagedCustomer `xml:"operand"`
@@ -100,18 +146,86 @@ func (s *ManagedCustomerService) Mutate(managedCustomerOperations ManagedCustome
 		Ops: operations,
 	}
 
-	respBody, err := s.Auth.request(managedCustomerServiceUrl, "mutate", mutation)
+	respBody, err := s.Auth.request(ctx, managedCustomerServiceUrl, "mutate", mutation)
 	if err != nil {
 		return managedCustomers, err
 	}
 
 	mutateResp := struct {
-		ManagedCustomers []ManagedCustomer `xml:"rval>value"`
+		ManagedCustomers     []ManagedCustomer    `xml:"rval>value"`
+		PartialFailureErrors partialFailureErrors `xml:"rval>partialFailureErrors"`
 	}{}
 	err = xml.Unmarshal([]byte(respBody), &mutateResp)
 	if err != nil {
 		return managedCustomers, err
 	}
 
-	return mutateResp.ManagedCustomers, err
+	return mutateResp.ManagedCustomers, partialFailure(operations, mutateResp.PartialFailureErrors)
+}
+
+// ClientCustomerIds returns the client accounts below the manager account
+// managerId, following the ManagedCustomerLinks of its hierarchy down to
+// the accounts which do not manage clients.  Links that are not ACTIVE are
+// not followed.
+//
+// Example
+//
+//   customerIds, err := managedCustomerService.ClientCustomerIds("123-456-7890")
+//
+func (s *ManagedCustomerService) ClientCustomerIds(managerId string, opts ...CallOption) (customerIds []string, err error) {
+	return s.ClientCustomerIdsWithContext(context.Background(), managerId, opts...)
+}
+
+// ClientCustomerIdsWithContext is the same as ClientCustomerIds with the addition of a context.
+func (s *ManagedCustomerService) ClientCustomerIdsWithContext(ctx context.Context, managerId string, opts ...CallOption) (customerIds []string, err error) {
+	root, err := strconv.ParseInt(strings.Replace(managerId, "-", "", -1), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("gads: invalid manager customer id %q", managerId)
+	}
+	ctx = withCallOptions(ctx, append([]CallOption{WithCustomerId(managerId)}, opts...))
+
+	managers := map[int64]bool{}
+	children := map[int64][]int64{}
+	selector := Selector{
+		Fields:   []string{"CustomerId", "Name", "CanManageClients"},
+		Ordering: []OrderBy{{Field: "CustomerId", SortOrder: "ASCENDING"}},
+		Paging:   &Paging{Offset: 0, Limit: DefaultPageSize},
+	}
+	for {
+		page, totalCount, err := s.GetWithContext(ctx, selector)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range page.ManagedCustomers {
+			managers[c.CustomerId] = c.CanManageClients
+		}
+		for _, l := range page.ManagedCustomerLinks {
+			if l.LinkStatus == "" || l.LinkStatus == "ACTIVE" {
+				children[l.ManagerCustomerId] = append(children[l.ManagerCustomerId], l.ClientCustomerId)
+			}
+		}
+		selector.Paging.Offset += selector.Paging.Limit
+		if len(page.ManagedCustomers) == 0 || selector.Paging.Offset >= totalCount {
+			break
+		}
+	}
+
+	seen := map[int64]bool{root: true}
+	queue := []int64{root}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, child := range children[id] {
+			if seen[child] {
+				continue
+			}
+			seen[child] = true
+			if managers[child] {
+				queue = append(queue, child)
+			} else {
+				customerIds = append(customerIds, strconv.FormatInt(child, 10))
+			}
+		}
+	}
+	return customerIds, nil
 }
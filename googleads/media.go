@@ -1,6 +1,7 @@
 package v201809
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/xml"
 )
@@ -73,9 +74,16 @@ func NewVideo(mediaType string) (image Media) {
 	}
 }
 
-func (s *MediaService) Get(selector Selector) (medias []Media, totalCount int64, err error) {
+func (s *MediaService) Get(selector Selector, opts ...CallOption) (medias []Media, totalCount int64, err error) {
+	return s.GetWithContext(context.Background(), selector, opts...)
+}
+
+// GetWithContext is the same as Get with the addition of a context.
+func (s *MediaService) GetWithContext(ctx context.Context, selector Selector, opts ...CallOption) (medias []Media, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 	selector.XMLName = xml.Name{baseUrl, "serviceSelector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		mediaServiceUrl,
 		"get",
 		struct {
@@ -103,11 +111,45 @@ func (s *MediaService) Get(selector Selector) (medias []Media, totalCount int64,
 	return getResp.Medias, getResp.Size, err
 }
 
+// MediaIterator walks the media of a selector, see
+// MediaService.Iterate.
+type MediaIterator struct {
+	*pageIterator
+}
+
+// Media returns the current media.
+func (it *MediaIterator) Media() Media {
+	return it.page.([]Media)[it.index]
+}
+
+// Iterate returns an iterator over all media matching selector, which
+// fetches them page by page, see CampaignService.Iterate.
+func (s *MediaService) Iterate(selector Selector, opts ...CallOption) *MediaIterator {
+	return s.IterateWithContext(context.Background(), selector, opts...)
+}
+
+// IterateWithContext is the same as Iterate with the addition of a context,
+// the iteration stops with the error of the context once it is done.
+func (s *MediaService) IterateWithContext(ctx context.Context, selector Selector, opts ...CallOption) *MediaIterator {
+	it := &MediaIterator{pageIterator: newPageIterator(ctx, selector, "MediaId")}
+	it.fetch = func(ctx context.Context, selector Selector) (interface{}, int, int64, error) {
+		medias, totalCount, err := s.GetWithContext(ctx, selector, opts...)
+		return medias, len(medias), totalCount, err
+	}
+	it.id = func(i int) int64 { return it.page.([]Media)[i].Id }
+	return it
+}
+
 func (s *MediaService) Query(query string) (medias []Media, totalCount int64, err error) {
 	return medias, totalCount, ERROR_NOT_YET_IMPLEMENTED
 }
 
 func (s *MediaService) Upload(medias []Media) (uploadedMedias []Media, err error) {
+	return s.UploadWithContext(context.Background(), medias)
+}
+
+// UploadWithContext is the same as Upload with the addition of a context.
+func (s *MediaService) UploadWithContext(ctx context.Context, medias []Media) (uploadedMedias []Media, err error) {
 	upload := struct {
 		XMLName xml.Name
 		Medias  []Media `xml:"media"`
@@ -118,7 +160,7 @@ func (s *MediaService) Upload(medias []Media) (uploadedMedias []Media, err error
 		},
 		Medias: medias,
 	}
-	respBody, err := s.Auth.request(mediaServiceUrl, "upload", upload)
+	respBody, err := s.Auth.request(ctx, mediaServiceUrl, "upload", upload)
 	if err != nil {
 		return uploadedMedias, err
 	}
@@ -0,0 +1,160 @@
+package v201809
+
+import (
+	"context"
+	"strconv"
+)
+
+// CallOption overrides a request header field of Auth for a single call,
+// services copy Auth when they are created so this avoids creating a new
+// service to switch accounts or to validate a single mutate.
+//
+// Example
+//
+//   campaigns, err := campaignService.Mutate(
+//     operations,
+//     gads.WithCustomerId("123-456-7890"),
+//     gads.WithValidateOnly(true),
+//   )
+//
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	customerId     *string
+	partialFailure *bool
+	validateOnly   *bool
+
+	// report downloads
+	skipColumnHeader       *bool
+	skipReportHeader       *bool
+	skipReportSummary      *bool
+	includeZeroImpressions *bool
+	useRawEnumValues       *bool
+}
+
+// WithCustomerId sends the request on behalf of the account customerId
+// instead of Auth.CustomerId.
+func WithCustomerId(customerId string) CallOption {
+	return func(o *callOptions) {
+		o.customerId = &customerId
+	}
+}
+
+// WithPartialFailure overrides Auth.PartialFailure.
+func WithPartialFailure(enabled bool) CallOption {
+	return func(o *callOptions) {
+		o.partialFailure = &enabled
+	}
+}
+
+// WithValidateOnly overrides Auth.ValidateOnly.
+func WithValidateOnly(enabled bool) CallOption {
+	return func(o *callOptions) {
+		o.validateOnly = &enabled
+	}
+}
+
+// WithSkipColumnHeader leaves out the header naming the columns of a
+// report download, ReportReader then matches columns on its Fields only.
+func WithSkipColumnHeader(enabled bool) CallOption {
+	return func(o *callOptions) {
+		o.skipColumnHeader = &enabled
+	}
+}
+
+// WithSkipReportHeader controls the line naming a report download and its
+// date range, which is skipped by default.
+func WithSkipReportHeader(enabled bool) CallOption {
+	return func(o *callOptions) {
+		o.skipReportHeader = &enabled
+	}
+}
+
+// WithSkipReportSummary controls the totals row of a report download,
+// which is skipped by default.  ReportReader returns it by Totals rather
+// than as a row, for CSV and TSV reports only; the summary of XML reports
+// is ignored and Totals returns ErrXMLReportTotals.
+func WithSkipReportSummary(enabled bool) CallOption {
+	return func(o *callOptions) {
+		o.skipReportSummary = &enabled
+	}
+}
+
+// WithIncludeZeroImpressions includes the rows without impressions in a
+// report download, if the report supports it.
+func WithIncludeZeroImpressions(enabled bool) CallOption {
+	return func(o *callOptions) {
+		o.includeZeroImpressions = &enabled
+	}
+}
+
+// WithUseRawEnumValues returns enum values of a report download as in the
+// API, e.g. ENABLED instead of enabled.
+func WithUseRawEnumValues(enabled bool) CallOption {
+	return func(o *callOptions) {
+		o.useRawEnumValues = &enabled
+	}
+}
+
+type callOptionsKey struct{}
+
+// withCallOptions returns a context carrying opts on top of the options
+// already set on ctx.
+func withCallOptions(ctx context.Context, opts []CallOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+	o := callOptionsFrom(ctx)
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return context.WithValue(ctx, callOptionsKey{}, o)
+}
+
+func callOptionsFrom(ctx context.Context) callOptions {
+	o, _ := ctx.Value(callOptionsKey{}).(callOptions)
+	return o
+}
+
+// customerId returns the account requests made with ctx are sent for.
+func (a *Auth) customerId(ctx context.Context) string {
+	if o := callOptionsFrom(ctx); o.customerId != nil {
+		return *o.customerId
+	}
+	return a.CustomerId
+}
+
+func (a *Auth) partialFailure(ctx context.Context) bool {
+	if o := callOptionsFrom(ctx); o.partialFailure != nil {
+		return *o.partialFailure
+	}
+	return a.PartialFailure
+}
+
+func (a *Auth) validateOnly(ctx context.Context) bool {
+	if o := callOptionsFrom(ctx); o.validateOnly != nil {
+		return *o.validateOnly
+	}
+	return a.ValidateOnly
+}
+
+// reportHeaders returns the headers of a report download made with o,
+// skipping the report header and summary unless told otherwise.
+func (o callOptions) reportHeaders() map[string]string {
+	headers := map[string]string{
+		"skipReportHeader":  "true",
+		"skipReportSummary": "true",
+	}
+	for name, v := range map[string]*bool{
+		"skipColumnHeader":       o.skipColumnHeader,
+		"skipReportHeader":       o.skipReportHeader,
+		"skipReportSummary":      o.skipReportSummary,
+		"includeZeroImpressions": o.includeZeroImpressions,
+		"useRawEnumValues":       o.useRawEnumValues,
+	} {
+		if v != nil {
+			headers[name] = strconv.FormatBool(*v)
+		}
+	}
+	return headers
+}
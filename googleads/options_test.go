@@ -0,0 +1,37 @@
+package v201809
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCallOptions(t *testing.T) {
+	client := &recordingClient{countingClient: countingClient{status: 200, body: soapGetResponse("")}}
+	auth := &Auth{Client: client, CustomerId: "111-111-1111", PartialFailure: true}
+	service := NewCampaignService(auth)
+
+	if _, _, err := service.Get(Selector{}, WithCustomerId("222-222-2222"), WithPartialFailure(false), WithValidateOnly(true)); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := service.Get(Selector{}); err != nil {
+		t.Fatal(err)
+	}
+
+	overridden, defaults := client.bodies[0], client.bodies[1]
+	for _, expected := range []string{"<clientCustomerId>222-222-2222</clientCustomerId>", "<validateOnly>true</validateOnly>"} {
+		if !strings.Contains(overridden, expected) {
+			t.Errorf("expected %s in\n%s", expected, overridden)
+		}
+	}
+	if strings.Contains(overridden, "partialFailure") {
+		t.Errorf("expected partialFailure to be disabled in\n%s", overridden)
+	}
+	for _, expected := range []string{"<clientCustomerId>111-111-1111</clientCustomerId>", "<partialFailure>true</partialFailure>"} {
+		if !strings.Contains(defaults, expected) {
+			t.Errorf("expected the options not to outlive the call, %s missing in\n%s", expected, defaults)
+		}
+	}
+	if auth.CustomerId != "111-111-1111" {
+		t.Errorf("expected Auth to be unchanged, got %q", auth.CustomerId)
+	}
+}
@@ -0,0 +1,158 @@
+package v201809
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// OperationFailure is an operation of a mutate sent with partial failure
+// enabled that was not applied.
+type OperationFailure struct {
+	Index    int          // position of the operation in the mutate
+	Operator string       // ADD, SET or REMOVE
+	Operand  interface{}  // operand as it was submitted
+	Errors   []FaultError // why the operation failed
+}
+
+// PartialFailureError is returned along with the results of a mutate sent
+// with partial failure enabled when some of its operations failed, the
+// other operations were applied.
+//
+// The results hold a value for every operation in the order they were sent,
+// the value of a failed operation is empty.  Campaigns and campaign
+// criteria of failed operations are the submitted operand with its Errors
+// set instead.
+//
+// Example
+//
+//   criteria, err := adGroupCriterionService.MutateOperations(operations, gads.WithPartialFailure(true))
+//   var pfe *gads.PartialFailureError
+//   if errors.As(err, &pfe) {
+//     for _, failure := range pfe.Failures {
+//       fmt.Printf("%#v failed: %v\n", failure.Operand, failure.Errors)
+//     }
+//   }
+//
+type PartialFailureError struct {
+	Failures []OperationFailure // ordered by Index
+	Errors   []FaultError       // errors not tied to an operation
+}
+
+func (e *PartialFailureError) Error() string {
+	msgs := []string{}
+	for _, f := range e.Failures {
+		for _, fe := range f.Errors {
+			msgs = append(msgs, fe.Error())
+		}
+	}
+	for _, fe := range e.Errors {
+		msgs = append(msgs, fe.Error())
+	}
+	return fmt.Sprintf("%d operations failed: %s", len(e.Failures), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of all operations so that errors.As and
+// errors.Is can match them.
+func (e *PartialFailureError) Unwrap() []error {
+	errs := []error{}
+	for _, f := range e.Failures {
+		for _, fe := range f.Errors {
+			errs = append(errs, fe)
+		}
+	}
+	for _, fe := range e.Errors {
+		errs = append(errs, fe)
+	}
+	return errs
+}
+
+// Failure returns the failure of the index'th operation of the mutate, or
+// nil if it was applied.
+func (e *PartialFailureError) Failure(index int) *OperationFailure {
+	i := sort.Search(len(e.Failures), func(i int) bool { return e.Failures[i].Index >= index })
+	if i < len(e.Failures) && e.Failures[i].Index == index {
+		return &e.Failures[i]
+	}
+	return nil
+}
+
+// errs returns the errors of f as a slice of error, the type of the Errors
+// fields of Campaign and CampaignCriterion.
+func (f OperationFailure) errs() []error {
+	errs := make([]error, len(f.Errors))
+	for i, fe := range f.Errors {
+		errs[i] = fe
+	}
+	return errs
+}
+
+// partialFailureErrors decodes the rval>partialFailureErrors of a mutate.
+type partialFailureErrors []FaultError
+
+func (p *partialFailureErrors) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	errorType, _ := findAttr(start.Attr, xml.Name{Space: "http://www.w3.org/2001/XMLSchema-instance", Local: "type"})
+	e, err := decodeFaultError(dec, start, errorType)
+	if err != nil {
+		return err
+	}
+	*p = append(*p, e)
+	return nil
+}
+
+var operationFieldPath = regexp.MustCompile(`^operations\[(\d+)\]`)
+
+// partialFailure returns a PartialFailureError mapping errs to the
+// operations they refer to, operations being the slice of operations sent
+// with the mutate.  It returns nil when errs is empty.
+func partialFailure(operations interface{}, errs partialFailureErrors) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	ops := reflect.ValueOf(operations)
+	pfe := &PartialFailureError{}
+	failures := map[int]int{} // operation index -> index in pfe.Failures
+	for _, e := range errs {
+		index := -1
+		if fp, ok := e.(interface{ fieldPath() string }); ok {
+			if m := operationFieldPath.FindStringSubmatch(fp.fieldPath()); m != nil {
+				index, _ = strconv.Atoi(m[1])
+			}
+		}
+		if index < 0 || index >= ops.Len() {
+			pfe.Errors = append(pfe.Errors, e)
+			continue
+		}
+		i, ok := failures[index]
+		if !ok {
+			operator, operand := operationFields(ops.Index(index))
+			pfe.Failures = append(pfe.Failures, OperationFailure{Index: index, Operator: operator, Operand: operand})
+			i = len(pfe.Failures) - 1
+			failures[index] = i
+		}
+		pfe.Failures[i].Errors = append(pfe.Failures[i].Errors, e)
+	}
+	sort.Slice(pfe.Failures, func(i, j int) bool { return pfe.Failures[i].Index < pfe.Failures[j].Index })
+	return pfe
+}
+
+// operationFields returns the fields of an operation struct tagged operator
+// and operand.
+func operationFields(op reflect.Value) (operator string, operand interface{}) {
+	t := op.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("xml"), ",")[0]
+		name = name[strings.LastIndex(name, " ")+1:]
+		switch name {
+		case "operator":
+			operator = op.Field(i).String()
+		case "operand":
+			operand = op.Field(i).Interface()
+		}
+	}
+	return operator, operand
+}
@@ -0,0 +1,102 @@
+package v201809
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func soapMutateResponse(rval string) string {
+	return `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><mutateResponse xmlns="https://adwords.google.com/api/adwords/cm/v201809"><rval>` + rval + `</rval></mutateResponse></soap:Body></soap:Envelope>`
+}
+
+func TestPartialFailureAdGroupCriteria(t *testing.T) {
+	keyword := func(id int64) string {
+		return `<value xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="BiddableAdGroupCriterion"><adGroupId>5</adGroupId><criterionUse>BIDDABLE</criterionUse><criterion xsi:type="Keyword"><id>` + strconv.FormatInt(id, 10) + `</id><type>KEYWORD</type><Criterion.Type>Keyword</Criterion.Type><text>shoes</text><matchType>EXACT</matchType></criterion></value>`
+	}
+	client := &recordingClient{countingClient: countingClient{status: 200, body: soapMutateResponse(
+		keyword(1) + `<value/>` + keyword(3) +
+			`<partialFailureErrors xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="PolicyViolationError"><fieldPath>operations[1].operand.criterion.text</fieldPath><trigger>cheap pills</trigger><errorString>PolicyViolationError.POLICY_ERROR</errorString><ApiError.Type>PolicyViolationError</ApiError.Type><key><policyName>pharmacy</policyName><violatingText>cheap pills</violatingText></key><isExemptable>true</isExemptable></partialFailureErrors>` +
+			`<partialFailureErrors xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CriterionError"><fieldPath>operations[1].operand.criterion.text</fieldPath><errorString>CriterionError.KEYWORD_HAS_INVALID_CHARS</errorString><reason>KEYWORD_HAS_INVALID_CHARS</reason></partialFailureErrors>`,
+	)}}
+	auth := &Auth{Client: client}
+
+	operations := []AdGroupCriterionOperation{
+		{"ADD", BiddableAdGroupCriterion{AdGroupId: 5, Criterion: KeywordCriterion{Text: "shoes", MatchType: "EXACT"}}},
+		{"ADD", BiddableAdGroupCriterion{AdGroupId: 5, Criterion: KeywordCriterion{Text: "cheap pills", MatchType: "EXACT"}}},
+		{"ADD", BiddableAdGroupCriterion{AdGroupId: 5, Criterion: KeywordCriterion{Text: "boots", MatchType: "EXACT"}}},
+	}
+	criteria, err := NewAdGroupCriterionService(auth).MutateOperations(operations, WithPartialFailure(true))
+	if !strings.Contains(client.bodies[0], "<partialFailure>true</partialFailure>") {
+		t.Errorf("expected partial failure to be requested\n%s", client.bodies[0])
+	}
+
+	if len(criteria) != 3 || criteria[1] != nil {
+		t.Fatalf("expected a result per operation, got %#v", criteria)
+	}
+	if _, ok := criteria[2].(BiddableAdGroupCriterion); !ok {
+		t.Errorf("got %#v", criteria[2])
+	}
+
+	var pfe *PartialFailureError
+	if !errors.As(err, &pfe) {
+		t.Fatalf("expected a PartialFailureError, got %#v", err)
+	}
+	if len(pfe.Failures) != 1 || pfe.Failure(0) != nil || pfe.Failure(2) != nil {
+		t.Fatalf("got failures %+v", pfe.Failures)
+	}
+	failure := pfe.Failure(1)
+	if failure.Operator != "ADD" || failure.Operand.(BiddableAdGroupCriterion).Criterion.(KeywordCriterion).Text != "cheap pills" {
+		t.Errorf("got failure %+v", failure)
+	}
+	if len(failure.Errors) != 2 {
+		t.Errorf("got errors %v", failure.Errors)
+	}
+
+	var pve PolicyViolationError
+	if !errors.As(err, &pve) || pve.Key.PolicyName != "pharmacy" {
+		t.Errorf("expected the policy violation to be reachable with errors.As, got %+v", pve)
+	}
+	if !errors.Is(err, CriterionError{EntityError{Reason: "KEYWORD_HAS_INVALID_CHARS"}}) {
+		t.Errorf("expected the criterion error to be reachable with errors.Is")
+	}
+}
+
+func TestPartialFailureCampaigns(t *testing.T) {
+	client := &countingClient{status: 200, body: soapMutateResponse(
+		`<value><id>1</id><name>applied</name></value><value/>` +
+			`<partialFailureErrors xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CampaignError"><fieldPath>operations[1].operand.name</fieldPath><reason>DUPLICATE_CAMPAIGN_NAME</reason></partialFailureErrors>`,
+	)}
+	auth := &Auth{Client: client, PartialFailure: true}
+
+	campaigns, err := NewCampaignService(auth).MutateOperations([]CampaignOperation{
+		{Action: "ADD", Campaign: Campaign{Name: "applied"}},
+		{Action: "ADD", Campaign: Campaign{Name: "duplicate"}},
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(campaigns) != 2 || campaigns[0].Id != 1 || len(campaigns[0].Errors) != 0 {
+		t.Fatalf("got %+v", campaigns)
+	}
+	if campaigns[1].Name != "duplicate" || len(campaigns[1].Errors) != 1 {
+		t.Errorf("expected the failed operand with its errors, got %+v", campaigns[1])
+	}
+	if !errors.Is(campaigns[1].Errors[0], CampaignError{EntityError{Reason: "DUPLICATE_CAMPAIGN_NAME"}}) {
+		t.Errorf("got %v", campaigns[1].Errors[0])
+	}
+}
+
+func TestPartialFailureNoErrors(t *testing.T) {
+	client := &countingClient{status: 200, body: soapMutateResponse(`<value><id>1</id><name>label</name></value>`)}
+	auth := &Auth{Client: client, PartialFailure: true}
+
+	labels, err := NewLabelService(auth).Mutate(LabelOperations{"ADD": {Label{Name: "label"}}})
+	if err != nil {
+		t.Fatalf("expected no error, got %#v", err)
+	}
+	if len(labels) != 1 {
+		t.Errorf("got %+v", labels)
+	}
+}
@@ -0,0 +1,200 @@
+package v201809
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+)
+
+// ExemptionRequest asks for an ad or keyword to be reviewed despite
+// violating the policy identified by Key.
+type ExemptionRequest struct {
+	Key PolicyViolationKey `xml:"key"`
+}
+
+// exemptionPlan tells which operations of a failed mutate to send again
+// with exemption requests.
+type exemptionPlan struct {
+	resend   []int                      // indexes of the operations to send again
+	requests map[int][]ExemptionRequest // exemption requests by operation index
+	failures []OperationFailure         // operations that cannot be exempted
+}
+
+// planExemptions inspects err, returned by a mutate of operations, for
+// exemptable policy violations.  Operations failing only because of such
+// violations are sent again with exemption requests.  Without partial
+// failure nothing was applied, so the operations without errors are sent
+// again as well.  It returns false if err holds no exemptable violation.
+func planExemptions(operations interface{}, err error) (plan exemptionPlan, ok bool) {
+	pfe, applied := operationFailures(operations, err)
+	if pfe == nil {
+		return plan, false
+	}
+
+	plan.requests = map[int][]ExemptionRequest{}
+	failed := map[int]bool{}
+	for _, failure := range pfe.Failures {
+		if requests, ok := exemptionRequests(failure.Errors); ok {
+			plan.requests[failure.Index] = requests
+		} else {
+			plan.failures = append(plan.failures, failure)
+		}
+		failed[failure.Index] = true
+	}
+	if len(plan.requests) == 0 {
+		return plan, false
+	}
+
+	for i := 0; i < reflect.ValueOf(operations).Len(); i++ {
+		if _, ok := plan.requests[i]; ok || (!applied && !failed[i]) {
+			plan.resend = append(plan.resend, i)
+		}
+	}
+	return plan, true
+}
+
+// exemptionRequests returns exemption requests for errs, or false if any of
+// them is not an exemptable policy violation.
+func exemptionRequests(errs []FaultError) (requests []ExemptionRequest, ok bool) {
+	for _, e := range errs {
+		pve, ok := e.(PolicyViolationError)
+		if !ok || !pve.IsExemptable {
+			return nil, false
+		}
+		requests = append(requests, ExemptionRequest{Key: pve.Key})
+	}
+	return requests, len(requests) > 0
+}
+
+// resubmitted completes plan with the outcome of sending its operations
+// again, the failures of err refer to the resent operations.  Without
+// partial failure a failed resubmission applied nothing, err is returned.
+func (plan *exemptionPlan) resubmitted(resent interface{}, err error) error {
+	if err == nil {
+		return nil
+	}
+	pfe, applied := operationFailures(resent, err)
+	if pfe == nil || len(pfe.Errors) > 0 || !applied {
+		return err
+	}
+	for _, failure := range pfe.Failures {
+		failure.Index = plan.resend[failure.Index]
+		plan.failures = append(plan.failures, failure)
+	}
+	sort.Slice(plan.failures, func(i, j int) bool { return plan.failures[i].Index < plan.failures[j].Index })
+	return nil
+}
+
+// operationFailures maps the errors of err, returned by a mutate of
+// operations, to the operations.  applied reports whether the other
+// operations were applied, i.e. partial failure was enabled.
+func operationFailures(operations interface{}, err error) (pfe *PartialFailureError, applied bool) {
+	if errors.As(err, &pfe) {
+		return pfe, true
+	}
+	var errs partialFailureErrors
+	for _, aef := range apiFaults(err) {
+		for _, e := range aef.Errors {
+			if fe, ok := e.(FaultError); ok {
+				errs = append(errs, fe)
+			}
+		}
+	}
+	pfe, _ = partialFailure(operations, errs).(*PartialFailureError)
+	return pfe, false
+}
+
+// ExemptPolicyViolations sends the operations that err, returned by
+// MutateOperations for operations, reports as violating exemptable policies
+// again with exemption requests for these policies.  Without partial
+// failure the operations without errors are sent again too since none were
+// applied.
+//
+// The returned ads are aligned with operations and hold the ads applied by
+// the resubmission, failures lists the operations that still failed,
+// including those with errors that cannot be exempted.  If err holds no
+// exemptable policy violation it is returned as is, as is the error of a
+// resubmission without partial failure which applied nothing.
+//
+// Example
+//
+//   ads, err := adGroupAdService.MutateOperations(operations)
+//   if err != nil {
+//     ads, failures, err = adGroupAdService.ExemptPolicyViolations(operations, err)
+//   }
+//
+func (s *AdGroupAdService) ExemptPolicyViolations(operations []AdGroupAdOperation, err error, opts ...CallOption) (adGroupAds AdGroupAds, failures []OperationFailure, e error) {
+	return s.ExemptPolicyViolationsWithContext(context.Background(), operations, err, opts...)
+}
+
+// ExemptPolicyViolationsWithContext is the same as ExemptPolicyViolations with the addition of a context.
+func (s *AdGroupAdService) ExemptPolicyViolationsWithContext(ctx context.Context, operations []AdGroupAdOperation, err error, opts ...CallOption) (adGroupAds AdGroupAds, failures []OperationFailure, e error) {
+	plan, ok := planExemptions(operations, err)
+	if !ok {
+		return nil, plan.failures, err
+	}
+
+	resend := []AdGroupAdOperation{}
+	for _, i := range plan.resend {
+		op := operations[i]
+		op.ExemptionRequests = append(append([]ExemptionRequest(nil), op.ExemptionRequests...), plan.requests[i]...)
+		resend = append(resend, op)
+	}
+	results, err := s.MutateOperationsWithContext(ctx, resend, opts...)
+	if err := plan.resubmitted(resend, err); err != nil {
+		return nil, plan.failures, err
+	}
+
+	adGroupAds = make(AdGroupAds, len(operations))
+	for j, i := range plan.resend {
+		if j < len(results) && results[j] != nil && plan.failure(i) == nil {
+			adGroupAds[i] = results[j]
+		}
+	}
+	return adGroupAds, plan.failures, nil
+}
+
+// ExemptPolicyViolations sends the operations that err, returned by
+// MutateOperations for operations, reports as violating exemptable policies
+// again with exemption requests, see AdGroupAdService.ExemptPolicyViolations.
+func (s *AdGroupCriterionService) ExemptPolicyViolations(operations []AdGroupCriterionOperation, err error, opts ...CallOption) (adGroupCriterions AdGroupCriterions, failures []OperationFailure, e error) {
+	return s.ExemptPolicyViolationsWithContext(context.Background(), operations, err, opts...)
+}
+
+// ExemptPolicyViolationsWithContext is the same as ExemptPolicyViolations with the addition of a context.
+func (s *AdGroupCriterionService) ExemptPolicyViolationsWithContext(ctx context.Context, operations []AdGroupCriterionOperation, err error, opts ...CallOption) (adGroupCriterions AdGroupCriterions, failures []OperationFailure, e error) {
+	plan, ok := planExemptions(operations, err)
+	if !ok {
+		return nil, plan.failures, err
+	}
+
+	resend := []AdGroupCriterionOperation{}
+	exemptions := [][]ExemptionRequest{}
+	for _, i := range plan.resend {
+		resend = append(resend, operations[i])
+		exemptions = append(exemptions, plan.requests[i])
+	}
+	results, err := s.mutateOperations(withCallOptions(ctx, opts), resend, exemptions)
+	if err := plan.resubmitted(resend, err); err != nil {
+		return nil, plan.failures, err
+	}
+
+	adGroupCriterions = make(AdGroupCriterions, len(operations))
+	for j, i := range plan.resend {
+		if j < len(results) && results[j] != nil && plan.failure(i) == nil {
+			adGroupCriterions[i] = results[j]
+		}
+	}
+	return adGroupCriterions, plan.failures, nil
+}
+
+// failure returns the failure of the index'th operation, if any.
+func (plan *exemptionPlan) failure(index int) *OperationFailure {
+	for i := range plan.failures {
+		if plan.failures[i].Index == index {
+			return &plan.failures[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package v201809
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestExemptPolicyViolationsPartialFailure(t *testing.T) {
+	keyword := func(id int64, text string) string {
+		return `<value xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="BiddableAdGroupCriterion"><adGroupId>5</adGroupId><criterionUse>BIDDABLE</criterionUse><criterion xsi:type="Keyword"><id>` + strconv.FormatInt(id, 10) + `</id><type>KEYWORD</type><Criterion.Type>Keyword</Criterion.Type><text>` + text + `</text><matchType>EXACT</matchType></criterion></value>`
+	}
+	client := &recordingClient{countingClient: countingClient{status: 200, body: soapMutateResponse(
+		keyword(1, "shoes") + `<value/><value/>` +
+			`<partialFailureErrors xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="PolicyViolationError"><fieldPath>operations[1].operand.criterion.text</fieldPath><errorString>PolicyViolationError.POLICY_ERROR</errorString><ApiError.Type>PolicyViolationError</ApiError.Type><key><policyName>pharmacy</policyName><violatingText>cheap pills</violatingText></key><isExemptable>true</isExemptable></partialFailureErrors>` +
+			`<partialFailureErrors xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="PolicyViolationError"><fieldPath>operations[2].operand.criterion.text</fieldPath><errorString>PolicyViolationError.POLICY_ERROR</errorString><ApiError.Type>PolicyViolationError</ApiError.Type><key><policyName>weapons</policyName><violatingText>guns</violatingText></key><isExemptable>false</isExemptable></partialFailureErrors>`,
+	)}}
+	auth := &Auth{Client: client, PartialFailure: true}
+	service := NewAdGroupCriterionService(auth)
+
+	operations := []AdGroupCriterionOperation{
+		{Action: "ADD", AdGroupCriterion: BiddableAdGroupCriterion{AdGroupId: 5, Criterion: KeywordCriterion{Text: "shoes", MatchType: "EXACT"}}},
+		{Action: "ADD", AdGroupCriterion: BiddableAdGroupCriterion{AdGroupId: 5, Criterion: KeywordCriterion{Text: "cheap pills", MatchType: "EXACT"}}},
+		{Action: "ADD", AdGroupCriterion: BiddableAdGroupCriterion{AdGroupId: 5, Criterion: KeywordCriterion{Text: "guns", MatchType: "EXACT"}}},
+	}
+	_, err := service.MutateOperations(operations)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	client.body = soapMutateResponse(keyword(2, "cheap pills"))
+	criteria, failures, err := service.ExemptPolicyViolations(operations, err)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resent := strings.Join(strings.Fields(client.bodies[1]), "")
+	if !strings.Contains(resent, "<exemptionRequests><key><policyName>pharmacy</policyName><violatingText>cheappills</violatingText></key></exemptionRequests>") {
+		t.Errorf("expected an exemption request in\n%s", resent)
+	}
+	if strings.Count(resent, "<operations>") != 1 {
+		t.Errorf("expected only the exemptable operation to be sent again\n%s", resent)
+	}
+	if len(criteria) != 3 || criteria[0] != nil || criteria[2] != nil {
+		t.Fatalf("expected results aligned with the operations, got %#v", criteria)
+	}
+	if criteria[1].(BiddableAdGroupCriterion).Criterion.(KeywordCriterion).Id != 2 {
+		t.Errorf("got %#v", criteria[1])
+	}
+	if len(failures) != 1 || failures[0].Index != 2 {
+		t.Errorf("expected the non exemptable operation to be reported, got %+v", failures)
+	}
+}
+
+func TestExemptPolicyViolationsAtomic(t *testing.T) {
+	policyViolation := `<key><policyName>trademarks</policyName><violatingText>acme</violatingText></key><isExemptable>true</isExemptable>`
+	fault := strings.Replace(testFault("PolicyViolationError", "POLICY_ERROR", policyViolation),
+		"<fieldPath></fieldPath>", "<fieldPath>operations[1].operand.ad.headlinePart1</fieldPath>", 1)
+	client := &recordingClient{countingClient: countingClient{status: 500, body: fault}}
+	auth := &Auth{Client: client, RetryPolicy: NoRetry}
+	service := NewAdGroupAdService(auth)
+
+	operations := []AdGroupAdOperation{
+		{Action: "ADD", AdGroupAd: ExpandedTextAd{AdGroupId: 1, HeadlinePart1: "shoes"}},
+		{Action: "ADD", AdGroupAd: ExpandedTextAd{AdGroupId: 1, HeadlinePart1: "acme"}},
+	}
+	_, err := service.MutateOperations(operations)
+
+	client.status = 200
+	client.body = soapMutateResponse(`<value><adGroupId>1</adGroupId><ad xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="ExpandedTextAd"><id>10</id></ad></value><value><adGroupId>1</adGroupId><ad xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="ExpandedTextAd"><id>11</id></ad></value>`)
+	ads, failures, err := service.ExemptPolicyViolations(operations, err)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(failures) != 0 {
+		t.Errorf("got failures %+v", failures)
+	}
+
+	resent := client.bodies[1]
+	if strings.Count(resent, "<operations>") != 2 || strings.Count(resent, "<exemptionRequests>") != 1 {
+		t.Errorf("expected both operations to be sent again, the second with an exemption request\n%s", resent)
+	}
+	if len(ads) != 2 || ads[0] == nil || ads[1] == nil {
+		t.Errorf("got %#v", ads)
+	}
+}
+
+func TestExemptPolicyViolationsAtomicResubmissionFails(t *testing.T) {
+	policyViolation := `<key><policyName>trademarks</policyName><violatingText>acme</violatingText></key><isExemptable>true</isExemptable>`
+	fault := strings.Replace(testFault("PolicyViolationError", "POLICY_ERROR", policyViolation),
+		"<fieldPath></fieldPath>", "<fieldPath>operations[1].operand.ad.headlinePart1</fieldPath>", 1)
+	client := &recordingClient{countingClient: countingClient{status: 500, body: fault}}
+	auth := &Auth{Client: client, RetryPolicy: NoRetry}
+	service := NewAdGroupAdService(auth)
+
+	operations := []AdGroupAdOperation{
+		{Action: "ADD", AdGroupAd: ExpandedTextAd{AdGroupId: 1, HeadlinePart1: "shoes"}},
+		{Action: "ADD", AdGroupAd: ExpandedTextAd{AdGroupId: 1, HeadlinePart1: "acme"}},
+	}
+	_, err := service.MutateOperations(operations)
+
+	// the exempted operation still fails, so neither is applied
+	client.body = strings.Replace(testFault("AdError", "INVALID_INPUT", ""),
+		"<fieldPath></fieldPath>", "<fieldPath>operations[1].operand.ad.headlinePart1</fieldPath>", 1)
+	ads, _, err := service.ExemptPolicyViolations(operations, err)
+	if !errors.Is(err, AdError{Reason: "INVALID_INPUT"}) {
+		t.Fatalf("expected the failed resubmission to be returned, got %v", err)
+	}
+	if ads != nil {
+		t.Errorf("expected no ads, got %#v", ads)
+	}
+}
+
+func TestExemptPolicyViolationsNotExemptable(t *testing.T) {
+	client := &countingClient{status: 500, body: testFault("CampaignError", "DUPLICATE_CAMPAIGN_NAME", "")}
+	auth := &Auth{Client: client, RetryPolicy: NoRetry}
+	service := NewAdGroupAdService(auth)
+
+	operations := []AdGroupAdOperation{{Action: "ADD", AdGroupAd: ExpandedTextAd{AdGroupId: 1}}}
+	_, mutateErr := service.MutateOperations(operations)
+	_, _, err := service.ExemptPolicyViolations(operations, mutateErr)
+	if err != mutateErr {
+		t.Errorf("expected the error to be returned as is, got %v", err)
+	}
+	if client.calls != 1 {
+		t.Errorf("expected nothing to be sent again, got %d calls", client.calls)
+	}
+}
@@ -0,0 +1,275 @@
+package v201809
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimiter is consulted by Auth before every SOAP call and report
+// download so parallel jobs stay within the AdWords rate limits.  A single
+// limiter is meant to be shared by all Auth values of a process.
+type RateLimiter interface {
+	// Wait blocks until a request for the customer may be sent or ctx is
+	// done.
+	Wait(ctx context.Context, developerToken, customerId string) error
+
+	// RateExceeded reports a RateExceededError returned by the API so the
+	// limiter can slow down the affected scope.
+	RateExceeded(developerToken, customerId string, err RateExceededError)
+}
+
+// RateBudget allows Requests requests every Interval.  A zero budget does
+// not limit.
+type RateBudget struct {
+	Requests int
+	Interval time.Duration
+}
+
+// RateLimits configures a BucketLimiter.
+type RateLimits struct {
+	Developer RateBudget // shared by all accounts of a developer token
+	Account   RateBudget // per developer token and customer id
+
+	// Backoff is the pause applied when a RateExceededError carries no
+	// retryAfterSeconds.
+	Backoff time.Duration
+
+	// Recovery is how long a slowed down scope has to stay clear of
+	// RateExceededErrors before its rate is doubled again.
+	Recovery time.Duration
+}
+
+// BucketLimiter is a token bucket RateLimiter with one bucket per
+// developer token (DEVELOPER scope) and one per developer token and
+// customer id (ACCOUNT scope).  Every RateExceededError pauses the bucket
+// of its scope and halves its rate, which is restored step by step once
+// no more errors are observed.  Buckets back to the state of a new one are
+// dropped every Recovery, so serving many accounts does not grow the
+// limiter for good.
+type BucketLimiter struct {
+	limits RateLimits
+
+	mu        sync.Mutex
+	buckets   map[string]*rateBucket
+	lastSweep time.Time
+}
+
+// NewRateLimiter creates a BucketLimiter enforcing limits.
+//
+// Example
+//
+//   limiter := gads.NewRateLimiter(gads.RateLimits{
+//     Developer: gads.RateBudget{Requests: 1000, Interval: time.Minute},
+//     Account:   gads.RateBudget{Requests: 100, Interval: time.Minute},
+//   })
+//   authConf.Auth.RateLimiter = limiter
+//
+func NewRateLimiter(limits RateLimits) *BucketLimiter {
+	if limits.Backoff == 0 {
+		limits.Backoff = 30 * time.Second
+	}
+	if limits.Recovery == 0 {
+		limits.Recovery = time.Minute
+	}
+	return &BucketLimiter{
+		limits:    limits,
+		buckets:   map[string]*rateBucket{},
+		lastSweep: time.Now(),
+	}
+}
+
+const minRateFactor = 1.0 / 64
+
+type rateBucket struct {
+	budget       RateBudget
+	factor       float64 // fraction of the budget currently allowed
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+	slowedAt     time.Time
+}
+
+func newRateBucket(budget RateBudget, now time.Time) *rateBucket {
+	return &rateBucket{
+		budget: budget,
+		factor: 1,
+		tokens: float64(budget.Requests),
+		last:   now,
+	}
+}
+
+func (b *rateBucket) refill(now time.Time, recovery time.Duration) {
+	if b.factor < 1 && now.Sub(b.slowedAt) >= recovery {
+		b.factor *= 2
+		if b.factor > 1 {
+			b.factor = 1
+		}
+		b.slowedAt = now
+	}
+	if b.budget.Requests <= 0 || b.budget.Interval <= 0 {
+		return
+	}
+	capacity := float64(b.budget.Requests) * b.factor
+	if capacity < 1 {
+		capacity = 1
+	}
+	b.tokens += now.Sub(b.last).Seconds() * b.rate()
+	if b.tokens > capacity {
+		b.tokens = capacity
+	}
+	b.last = now
+}
+
+// rate returns the current refill rate in tokens per second.
+func (b *rateBucket) rate() float64 {
+	return float64(b.budget.Requests) / b.budget.Interval.Seconds() * b.factor
+}
+
+// delay returns how long to wait before a token is available.
+func (b *rateBucket) delay(now time.Time) time.Duration {
+	if now.Before(b.blockedUntil) {
+		return b.blockedUntil.Sub(now)
+	}
+	if b.budget.Requests <= 0 || b.budget.Interval <= 0 || b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate() * float64(time.Second))
+}
+
+func (b *rateBucket) take() {
+	if b.budget.Requests > 0 && b.budget.Interval > 0 {
+		b.tokens--
+	}
+}
+
+// idle reports whether b, once refilled, is as a new bucket would be, so
+// dropping it changes nothing.
+func (b *rateBucket) idle(now time.Time, recovery time.Duration) bool {
+	b.refill(now, recovery)
+	if b.factor < 1 || now.Before(b.blockedUntil) {
+		return false
+	}
+	return b.budget.Requests <= 0 || b.budget.Interval <= 0 || b.tokens >= float64(b.budget.Requests)
+}
+
+func (b *rateBucket) slowDown(now time.Time, pause time.Duration) {
+	if until := now.Add(pause); until.After(b.blockedUntil) {
+		b.blockedUntil = until
+	}
+	b.factor /= 2
+	if b.factor < minRateFactor {
+		b.factor = minRateFactor
+	}
+	if b.tokens > 0 {
+		b.tokens = 0
+	}
+	b.slowedAt = now
+}
+
+func (l *BucketLimiter) bucket(key string, budget RateBudget, now time.Time) *rateBucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = newRateBucket(budget, now)
+		l.buckets[key] = b
+	}
+	return b
+}
+
+// sweep drops the idle buckets, at most once every Recovery.
+func (l *BucketLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.limits.Recovery {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if b.idle(now, l.limits.Recovery) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (l *BucketLimiter) scopeBuckets(developerToken, customerId string, now time.Time) (dev, account *rateBucket) {
+	l.sweep(now)
+	dev = l.bucket("DEVELOPER-"+developerToken, l.limits.Developer, now)
+	account = l.bucket("ACCOUNT-"+developerToken+"-"+customerId, l.limits.Account, now)
+	return dev, account
+}
+
+// Wait blocks until both the developer and the account bucket have a
+// token available and takes one from each.
+func (l *BucketLimiter) Wait(ctx context.Context, developerToken, customerId string) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		dev, account := l.scopeBuckets(developerToken, customerId, now)
+		dev.refill(now, l.limits.Recovery)
+		account.refill(now, l.limits.Recovery)
+		wait := dev.delay(now)
+		if d := account.delay(now); d > wait {
+			wait = d
+		}
+		if wait == 0 {
+			dev.take()
+			account.take()
+			l.mu.Unlock()
+			return nil
+		}
+		l.mu.Unlock()
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// RateExceeded pauses and slows down the bucket matching the error's
+// RateScope, the account bucket when the scope is unknown.
+func (l *BucketLimiter) RateExceeded(developerToken, customerId string, err RateExceededError) {
+	pause := time.Duration(err.RetryAfterSeconds) * time.Second
+	if pause == 0 {
+		pause = l.limits.Backoff
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	dev, account := l.scopeBuckets(developerToken, customerId, now)
+	if err.RateScope == "DEVELOPER" {
+		dev.slowDown(now, pause)
+	} else {
+		account.slowDown(now, pause)
+	}
+}
+
+func (a *Auth) waitRateLimit(ctx context.Context) error {
+	if a.RateLimiter == nil {
+		return nil
+	}
+	return a.RateLimiter.Wait(ctx, a.DeveloperToken, a.customerId(ctx))
+}
+
+// observeRateExceeded passes the RateExceededErrors found in err on to the
+// rate limiter, charging them to the account of the call.
+func (a *Auth) observeRateExceeded(ctx context.Context, err error) {
+	if a.RateLimiter == nil || err == nil {
+		return
+	}
+	customerId := a.customerId(ctx)
+	switch e := err.(type) {
+	case ApiError:
+		// report downloads only tell the reason, e.g. RateExceededError.RATE_EXCEEDED
+		if strings.HasPrefix(e.Type, "RateExceededError") {
+			a.RateLimiter.RateExceeded(a.DeveloperToken, customerId, RateExceededError{Reason: e.Code()})
+		}
+		return
+	}
+	for _, aef := range apiFaults(err) {
+		for _, e := range aef.Errors {
+			if ree, ok := e.(RateExceededError); ok {
+				a.RateLimiter.RateExceeded(a.DeveloperToken, customerId, ree)
+			}
+		}
+	}
+}
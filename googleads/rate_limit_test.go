@@ -0,0 +1,155 @@
+package v201809
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBudget(t *testing.T) {
+	limiter := NewRateLimiter(RateLimits{
+		Account: RateBudget{Requests: 2, Interval: 200 * time.Millisecond},
+	})
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(ctx, "token", "123"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("third request sent after %s, expected it to wait for a token", elapsed)
+	}
+
+	// other accounts have their own budget
+	start = time.Now()
+	if err := limiter.Wait(ctx, "token", "456"); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("unrelated account waited %s", elapsed)
+	}
+}
+
+func TestRateLimiterRateExceeded(t *testing.T) {
+	limiter := NewRateLimiter(RateLimits{Backoff: time.Hour})
+	limiter.RateExceeded("token", "123", RateExceededError{RateScope: "ACCOUNT"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx, "token", "123"); err != context.DeadlineExceeded {
+		t.Errorf("got %v, expected the account to be paused", err)
+	}
+	if err := limiter.Wait(context.Background(), "token", "456"); err != nil {
+		t.Errorf("got %v, expected other accounts to be unaffected", err)
+	}
+
+	limiter.RateExceeded("token", "456", RateExceededError{RateScope: "DEVELOPER"})
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx, "token", "789"); err != context.DeadlineExceeded {
+		t.Errorf("got %v, expected the developer token to be paused", err)
+	}
+	if err := limiter.Wait(context.Background(), "other-token", "789"); err != nil {
+		t.Errorf("got %v, expected other developer tokens to be unaffected", err)
+	}
+}
+
+func TestRateLimiterDropsIdleBuckets(t *testing.T) {
+	limiter := NewRateLimiter(RateLimits{
+		Account:  RateBudget{Requests: 100, Interval: 10 * time.Millisecond},
+		Backoff:  time.Hour,
+		Recovery: 20 * time.Millisecond,
+	})
+	ctx := context.Background()
+	for i := 0; i < 100; i++ {
+		if err := limiter.Wait(ctx, "token", strconv.Itoa(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	limiter.RateExceeded("token", "slow", RateExceededError{RateScope: "ACCOUNT"})
+
+	time.Sleep(30 * time.Millisecond)
+	if err := limiter.Wait(ctx, "token", "new"); err != nil {
+		t.Fatal(err)
+	}
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	// the developer bucket, the paused account and the new one
+	if len(limiter.buckets) != 3 {
+		t.Errorf("got %d buckets, expected the idle ones to be dropped", len(limiter.buckets))
+	}
+	if _, ok := limiter.buckets["ACCOUNT-token-slow"]; !ok {
+		t.Error("expected the paused account to be kept")
+	}
+}
+
+func TestRateLimiterObservesFaults(t *testing.T) {
+	limiter := NewRateLimiter(RateLimits{})
+	client := &countingClient{status: 500, body: testFault("RateExceededError", "RATE_EXCEEDED",
+		"<rateName>OperationsByMinute</rateName><rateScope>ACCOUNT</rateScope><retryAfterSeconds>60</retryAfterSeconds>")}
+	auth := &Auth{CustomerId: "123", Client: client, RetryPolicy: NoRetry, RateLimiter: limiter}
+
+	if _, _, err := NewCampaignService(auth).Get(Selector{}); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, _, err := NewCampaignService(auth).GetWithContext(ctx, Selector{}); err != context.DeadlineExceeded {
+		t.Errorf("got %v, expected the request to wait for the account to recover", err)
+	}
+	if client.calls != 1 {
+		t.Errorf("got %d calls, expected 1", client.calls)
+	}
+}
+
+// recordingLimiter records the accounts it is asked about.
+type recordingLimiter struct {
+	RateLimiter
+	waits    []string
+	exceeded []string
+}
+
+func (l *recordingLimiter) Wait(ctx context.Context, developerToken, customerId string) error {
+	l.waits = append(l.waits, customerId)
+	return l.RateLimiter.Wait(ctx, developerToken, customerId)
+}
+
+func (l *recordingLimiter) RateExceeded(developerToken, customerId string, err RateExceededError) {
+	l.exceeded = append(l.exceeded, customerId)
+	l.RateLimiter.RateExceeded(developerToken, customerId, err)
+}
+
+func TestRateLimiterPerCallCustomerId(t *testing.T) {
+	limiter := &recordingLimiter{RateLimiter: NewRateLimiter(RateLimits{})}
+	client := &countingClient{status: 500, body: testFault("RateExceededError", "RATE_EXCEEDED",
+		"<rateName>OperationsByMinute</rateName><rateScope>ACCOUNT</rateScope><retryAfterSeconds>60</retryAfterSeconds>")}
+	auth := &Auth{CustomerId: "123", Client: client, RetryPolicy: NoRetry, RateLimiter: limiter}
+	service := NewCampaignService(auth)
+
+	if _, _, err := service.Get(Selector{}, WithCustomerId("111")); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(limiter.waits) != 1 || limiter.waits[0] != "111" || len(limiter.exceeded) != 1 || limiter.exceeded[0] != "111" {
+		t.Fatalf("expected account 111 to be limited, got waits %q and errors %q", limiter.waits, limiter.exceeded)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, _, err := service.GetWithContext(ctx, Selector{}, WithCustomerId("111")); err != context.DeadlineExceeded {
+		t.Errorf("got %v, expected account 111 to be paused", err)
+	}
+	for _, customerId := range []string{"222", "123"} {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if _, _, err := service.GetWithContext(ctx, Selector{}, WithCustomerId(customerId)); err == context.DeadlineExceeded {
+			t.Errorf("expected account %s to have its own bucket", customerId)
+		}
+	}
+	if client.calls != 3 {
+		t.Errorf("got %d calls, expected 3", client.calls)
+	}
+}
@@ -1,6 +1,7 @@
 package v201809
 
 import (
+	"context"
 	"encoding/xml"
 )
 
@@ -44,8 +45,15 @@ func NewReportDefinitionService(auth *Auth) *ReportDefinitionService {
 	return &ReportDefinitionService{Auth: *auth}
 }
 
-func (s *ReportDefinitionService) GetReportFields(report string) (fields []ReportDefinitionField, err error) {
+func (s *ReportDefinitionService) GetReportFields(report string, opts ...CallOption) (fields []ReportDefinitionField, err error) {
+	return s.GetReportFieldsWithContext(context.Background(), report, opts...)
+}
+
+// GetReportFieldsWithContext is the same as GetReportFields with the addition of a context.
+func (s *ReportDefinitionService) GetReportFieldsWithContext(ctx context.Context, report string, opts ...CallOption) (fields []ReportDefinitionField, err error) {
+	ctx = withCallOptions(ctx, opts)
 	respBody, err := s.Auth.request(
+		ctx,
 		reportDefinitionServiceUrl,
 		"get",
 		struct {
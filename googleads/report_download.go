@@ -2,12 +2,14 @@ package v201809
 
 import (
 	"bytes"
-	"encoding/csv"
+	"context"
 	"encoding/xml"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 type ReportDownloadService struct {
@@ -40,11 +42,46 @@ func (s ApiError) Code() string {
 	return s.Type
 }
 
+// ErrorType returns the type part of Type, e.g. AuthorizationError.
+func (s ApiError) ErrorType() string {
+	return strings.SplitN(s.Type, ".", 2)[0]
+}
+
+// ErrorReason returns the reason part of Type, e.g. USER_PERMISSION_DENIED.
+func (s ApiError) ErrorReason() string {
+	if parts := strings.SplitN(s.Type, ".", 2); len(parts) > 1 {
+		return parts[1]
+	}
+	return ""
+}
+
 func NewReportDownloadService(auth *Auth) *ReportDownloadService {
 	return &ReportDownloadService{Auth: *auth}
 }
 
-func (s *ReportDownloadService) Get(reportDefinition ReportDefinition) (res interface{}, err error) {
+func (s *ReportDownloadService) Get(reportDefinition ReportDefinition, opts ...CallOption) (res interface{}, err error) {
+	return s.GetWithContext(context.Background(), reportDefinition, opts...)
+}
+
+// GetWithContext is the same as Get with the addition of a context.
+func (s *ReportDownloadService) GetWithContext(ctx context.Context, reportDefinition ReportDefinition, opts ...CallOption) (res interface{}, err error) {
+	ctx = withCallOptions(ctx, opts)
+	form, err := definitionForm(reportDefinition)
+	if err != nil {
+		return res, err
+	}
+	resp, err := s.makeRequest(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return parseReport(ctx, resp.Body, reportDefinition.DownloadFormat, reportDefinition.Selector.Fields)
+}
+
+// definitionForm returns the form downloading the report of
+// reportDefinition.
+func definitionForm(reportDefinition ReportDefinition) (url.Values, error) {
 	reportDefinition.Selector.XMLName = xml.Name{baseUrl, "selector"}
 	repDef := reportDefinitionXml{
 		ReportDefinition: &reportDefinition,
@@ -55,91 +92,185 @@ func (s *ReportDownloadService) Get(reportDefinition ReportDefinition) (res inte
 	}
 	body, err := xml.MarshalIndent(repDef, "  ", "  ")
 	if err != nil {
-		return res, err
+		return nil, err
 	}
 	form := url.Values{}
 	form.Add("__rdxml", string(body))
-	resp, err := s.makeRequest(form)
-	if err != nil {
-		return res, err
-	}
-	defer resp.Body.Close()
+	return form, nil
+}
 
-	if resp.StatusCode != 200 {
-		dec := xml.NewDecoder(resp.Body)
-		el := &ReportDownloadError{}
-		if err := dec.Decode(el); err != nil {
-			return nil, err
-		}
-		return nil, el.ApiError
-	}
-	return parseReport(resp.Body)
+// StreamAWQL returns the unread report of the AWQL query awql in format fmt.
+// Transient errors before the report starts being sent are retried
+// according to the RetryPolicy of Auth.
+func (s *ReportDownloadService) StreamAWQL(awql string, fmt string, opts ...CallOption) (io.ReadCloser, error) {
+	return s.StreamAWQLWithContext(context.Background(), awql, fmt, opts...)
 }
 
-func (s *ReportDownloadService) StreamAWQL(awql string, fmt string) (io.ReadCloser, error) {
+// StreamAWQLWithContext is the same as StreamAWQL with the addition of a
+// context.  The context also governs reading the returned body, so it must
+// not be cancelled until the caller is done with the stream.
+func (s *ReportDownloadService) StreamAWQLWithContext(ctx context.Context, awql string, fmt string, opts ...CallOption) (io.ReadCloser, error) {
+	ctx = withCallOptions(ctx, opts)
 	form := url.Values{}
 	form.Add("__rdquery", awql)
 	form.Add("__fmt", fmt)
-	resp, err := s.makeRequest(form)
+	resp, err := s.makeRequest(ctx, form)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode != 200 {
-		dec := xml.NewDecoder(resp.Body)
-		el := &ReportDownloadError{}
-		if err := dec.Decode(el); err != nil {
-			return nil, err
-		}
-		return nil, el.ApiError
-	}
-
 	return resp.Body, nil
 }
 
-func (s *ReportDownloadService) AWQL(awql string, fmt string) (interface{}, error) {
-	body, err := s.StreamAWQL(awql, fmt)
+func (s *ReportDownloadService) AWQL(awql string, fmt string, opts ...CallOption) (interface{}, error) {
+	return s.AWQLWithContext(context.Background(), awql, fmt, opts...)
+}
+
+// AWQLWithContext is the same as AWQL with the addition of a context.
+func (s *ReportDownloadService) AWQLWithContext(ctx context.Context, awql string, fmt string, opts ...CallOption) (interface{}, error) {
+	ctx = withCallOptions(ctx, opts)
+	body, err := s.StreamAWQLWithContext(ctx, awql, fmt)
 	if err != nil {
 		return nil, err
 	}
 	defer body.Close()
 
-	return parseReport(body)
+	var fields []string
+	if q, err := ParseQuery(awql); err == nil {
+		fields = q.Fields
+	}
+	return parseReport(ctx, body, fmt, fields)
+}
+
+// Make our http request using the given form (re-usable for either XML or AWQL).
+// Responses other than 200 are returned as an ApiError.  Transient errors
+// are retried according to the RetryPolicy of Auth until the report starts
+// being sent.
+func (s *ReportDownloadService) makeRequest(ctx context.Context, form url.Values) (res *http.Response, err error) {
+	attempts := 0
+	err = s.withRetry(ctx, func() (bool, error) {
+		if attempts++; attempts > 1 {
+			stat.retry("ReportDownloadService", "download")
+		}
+		res, err = s.makeRequestOnce(ctx, form)
+		return true, err
+	})
+	return res, err
 }
 
-// Make our http request using the given form (re-usable for either XML or AWQL)
-func (s *ReportDownloadService) makeRequest(form url.Values) (res *http.Response, err error) {
-	req, err := http.NewRequest("POST", reportDownloadServiceUrl.Url, bytes.NewBufferString(form.Encode()))
+func (s *ReportDownloadService) makeRequestOnce(ctx context.Context, form url.Values) (res *http.Response, err error) {
+	startTime := time.Now()
+	ex := &Exchange{
+		ServiceUrl:  ServiceUrl{reportDownloadServiceUrl.Url, "ReportDownloadService"},
+		Action:      "download",
+		Method:      "POST",
+		Url:         s.endpointUrl(reportDownloadServiceUrl),
+		Header:      http.Header{},
+		RequestBody: []byte(form.Encode()),
+		stream:      true,
+	}
+	ex.Header.Add("developerToken", s.Auth.DeveloperToken)
+	ex.Header.Add("clientCustomerId", s.Auth.customerId(ctx))
+	for name, value := range callOptionsFrom(ctx).reportHeaders() {
+		ex.Header.Add(name, value)
+	}
+	ex.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	err = s.intercept(ctx, ex, s.reportHandler)
+	// an interceptor may have answered the exchange itself or replaced the
+	// response, passing on the error of the former one
+	if (err == nil || err == ex.Err) && ex.response == nil && (!ex.decoded || ex.undecoded()) {
+		err = decodeReportResponse(ex)
+	}
+	// the report itself is streamed to the caller and never logged
+	s.logExchange(ex, time.Since(startTime), err)
+	stat.count(ex.ServiceUrl.Name, ex.Action, false, false, time.Since(startTime), err)
 	if err != nil {
-		return res, err
+		if ex.response != nil {
+			ex.response.Body.Close()
+		}
+		return nil, err
+	}
+	if ex.response == nil {
+		ex.response = &http.Response{
+			StatusCode: ex.StatusCode,
+			Body:       ioutil.NopCloser(bytes.NewReader(ex.ResponseBody)),
+		}
+	}
+	return ex.response, nil
+}
+
+// reportHandler sends a report download, leaving successful responses
+// unread for the caller to stream.
+func (s *ReportDownloadService) reportHandler(ctx context.Context, ex *Exchange) error {
+	if err := s.waitRateLimit(ctx); err != nil {
+		return err
+	}
+	if err := send(s.Client)(ctx, ex); err != nil {
+		return err
+	}
+	if ex.StatusCode == http.StatusOK {
+		ex.setDecoded()
+		return nil
 	}
-	req.Header.Add("developerToken", s.Auth.DeveloperToken)
-	req.Header.Add("clientCustomerId", s.Auth.CustomerId)
-	req.Header.Add("skipReportHeader", "true")
-	req.Header.Add("skipReportSummary", "true")
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	return s.Client.Do(req)
+
+	// errors are small, read them for interceptors to inspect
+	defer ex.response.Body.Close()
+	body, err := ioutil.ReadAll(ex.response.Body)
+	ex.response = nil
+	if err != nil {
+		return err
+	}
+	ex.ResponseBody = body
+	return decodeReportResponse(ex)
 }
 
-func parseReport(report io.Reader) (collection []map[string]string, err error) {
-	reader := csv.NewReader(report)
-	header, err := reader.Read()
+// decodeReportResponse turns a report download error response into ex.Err.
+// Responses which are no reportDownloadError, e.g. from load balancers, are
+// returned as an HTTPError.
+func decodeReportResponse(ex *Exchange) error {
+	ex.setDecoded()
+	ex.Err = nil
+	if ex.StatusCode == http.StatusOK {
+		return nil
+	}
+	el := &ReportDownloadError{}
+	if err := xml.Unmarshal(ex.ResponseBody, el); err != nil || el.ApiError.Type == "" {
+		ex.Err = &HTTPError{StatusCode: ex.StatusCode, Body: ex.ResponseBody}
+		return ex.Err
+	}
+	ex.Err = el.ApiError
+	return ex.Err
+}
+
+// parseReport reads a report downloaded in format into rows of values by
+// column header, or by field if the column header was skipped.  The
+// summary row is left out.
+func parseReport(ctx context.Context, report io.Reader, format string, fields []string) (collection []map[string]string, err error) {
+	reader, err := newReportReader(report, format, callOptionsFrom(ctx))
 	if err != nil {
 		return collection, err
 	}
+	header, err := reader.Header()
+	if err != nil {
+		return collection, err
+	}
+	if len(header) == 0 {
+		header = fields
+	}
 	for {
-		record, err := reader.Read()
+		record, err := reader.next()
 		if err == io.EOF {
 			break
 		} else if err != nil {
 			return collection, err
 		}
 		row := make(map[string]string)
-		for i := 0; i < len(record); i++ {
+		for i := 0; i < len(record) && i < len(header); i++ {
 			column := header[i]
 			row[column] = record[i]
 		}
 		collection = append(collection, row)
 	}
-	return collection, err
+	return collection, nil
 }
@@ -0,0 +1,214 @@
+package v201809
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Report download formats, see
+// https://developers.google.com/adwords/api/docs/guides/reporting#download_formats
+const (
+	ReportFormatCSV         = "CSV"
+	ReportFormatCSVForExcel = "CSVFOREXCEL"
+	ReportFormatTSV         = "TSV"
+	ReportFormatXML         = "XML"
+	ReportFormatGzippedCSV  = "GZIPPED_CSV"
+	ReportFormatGzippedXML  = "GZIPPED_XML"
+)
+
+// reportRecords reads the records of a report, the column header first.
+// Records may be reused by the next call to Read.
+type reportRecords interface {
+	Read() ([]string, error)
+}
+
+// checkReportFormat returns an error if reports downloaded in format cannot
+// be read.
+func checkReportFormat(format string) error {
+	switch strings.ToUpper(format) {
+	case "", ReportFormatCSV, ReportFormatGzippedCSV, ReportFormatTSV, ReportFormatCSVForExcel,
+		ReportFormatXML, ReportFormatGzippedXML:
+		return nil
+	}
+	return fmt.Errorf("gads: unsupported report format %q", format)
+}
+
+// newReportRecords returns the records of the report r downloaded in format,
+// gunzipping it if it is compressed.
+func newReportRecords(r io.Reader, format string) (reportRecords, error) {
+	if err := checkReportFormat(format); err != nil {
+		return nil, err
+	}
+	r, err := gunzipReport(r)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToUpper(format) {
+	case ReportFormatTSV:
+		return newCSVRecords(r, '\t'), nil
+	case ReportFormatCSVForExcel:
+		return newCSVRecords(newUTF16Reader(r), '\t'), nil
+	case ReportFormatXML, ReportFormatGzippedXML:
+		return &xmlRecords{decoder: xml.NewDecoder(r)}, nil
+	}
+	return newCSVRecords(r, ','), nil
+}
+
+// gunzipReport returns r uncompressed if it starts with the gzip magic
+// number, else r as is.  Gzipped formats are gzipped regardless of the
+// Content-Encoding of the response.
+func gunzipReport(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil || magic[0] != 0x1f || magic[1] != 0x8b {
+		// short or empty reports are left for the format readers
+		return br, nil
+	}
+	return gzip.NewReader(br)
+}
+
+func newCSVRecords(r io.Reader, comma rune) *csv.Reader {
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	if comma == '\t' {
+		// values are not quoted in TSV reports
+		reader.LazyQuotes = true
+	}
+	return reader
+}
+
+// utf16Reader decodes UTF-16LE text, as of CSVFOREXCEL reports, to UTF-8,
+// dropping the byte order mark.
+type utf16Reader struct {
+	r       *bufio.Reader
+	started bool
+	buf     []byte // decoded bytes not read yet
+}
+
+func newUTF16Reader(r io.Reader) *utf16Reader {
+	return &utf16Reader{r: bufio.NewReader(r)}
+}
+
+func (u *utf16Reader) Read(p []byte) (int, error) {
+	for len(u.buf) == 0 {
+		c, err := u.readUnit()
+		if err != nil {
+			return 0, err
+		}
+		r := rune(c)
+		if utf16.IsSurrogate(r) {
+			c2, err := u.readUnit()
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			if err != nil {
+				return 0, err
+			}
+			r = utf16.DecodeRune(r, rune(c2))
+		}
+		if !u.started {
+			u.started = true
+			if r == '\uFEFF' {
+				continue
+			}
+		}
+		var enc [utf8.UTFMax]byte
+		u.buf = append(u.buf, enc[:utf8.EncodeRune(enc[:], r)]...)
+	}
+	n := copy(p, u.buf)
+	u.buf = u.buf[n:]
+	return n, nil
+}
+
+// readUnit reads a UTF-16LE code unit.
+func (u *utf16Reader) readUnit() (uint16, error) {
+	lo, err := u.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	hi, err := u.r.ReadByte()
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return 0, err
+	}
+	return uint16(hi)<<8 | uint16(lo), nil
+}
+
+// xmlRecords reads the rows of an XML report, which look like
+//
+//   <report>
+//     <table>
+//       <columns>
+//         <column name="campaignID" display="Campaign ID"/>
+//         ...
+//       </columns>
+//       <row campaignID="1" .../>
+//       ...
+//
+// The header holds the names of the columns, in order.
+type xmlRecords struct {
+	decoder *xml.Decoder
+	columns map[string]int // index of the columns by name
+	header  []string
+	started bool // whether the header was returned
+}
+
+func (x *xmlRecords) Read() ([]string, error) {
+	for {
+		token, err := x.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "column":
+				if x.columns == nil {
+					x.columns = map[string]int{}
+				}
+				name := xmlAttr(t, "name")
+				x.columns[name] = len(x.header)
+				x.header = append(x.header, name)
+			case "row":
+				if !x.started {
+					return nil, fmt.Errorf("gads: XML report row before its columns")
+				}
+				record := make([]string, len(x.header))
+				for _, a := range t.Attr {
+					if i, ok := x.columns[a.Name.Local]; ok {
+						record[i] = a.Value
+					}
+				}
+				return record, nil
+			}
+		case xml.EndElement:
+			if t.Name.Local == "columns" && !x.started {
+				x.started = true
+				if x.header == nil {
+					x.header = []string{}
+				}
+				return x.header, nil
+			}
+		}
+	}
+}
+
+func xmlAttr(el xml.StartElement, name string) string {
+	for _, a := range el.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,99 @@
+package v201809
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+const testXMLReport = `<?xml version='1.0' encoding='UTF-8' standalone='yes'?>
+<report>
+  <report-name name="campaigns"/>
+  <date-range date="Jan 1, 2018-Jan 31, 2018"/>
+  <table>
+    <columns>
+      <column name="campaignID" display="Campaign ID"/>
+      <column name="campaign" display="Campaign"/>
+      <column name="cost" display="Cost"/>
+    </columns>
+    <row campaignID="1" campaign="Brand &amp; Co" cost="1500000"/>
+    <row cost="0" campaignID="2" campaign="Generic"/>
+  </table>
+</report>`
+
+func gzipped(s string) []byte {
+	var b bytes.Buffer
+	w := gzip.NewWriter(&b)
+	w.Write([]byte(s))
+	w.Close()
+	return b.Bytes()
+}
+
+func utf16LE(s string) []byte {
+	var b bytes.Buffer
+	for _, c := range utf16.Encode([]rune(s)) {
+		b.WriteByte(byte(c))
+		b.WriteByte(byte(c >> 8))
+	}
+	return b.Bytes()
+}
+
+func TestReportFormats(t *testing.T) {
+	type row struct {
+		CampaignId int64   `report:"CampaignId"`
+		Campaign   string  `report:"CampaignName"`
+		Cost       float64 `report:"Cost,micros"`
+	}
+	expected := []row{{1, "Brand & Co", 1.5}, {2, "Generic", 0}}
+	csv := "Campaign ID,Campaign,Cost\n1,Brand & Co,1500000\n2,Generic,0\n"
+	reports := map[string][]byte{
+		ReportFormatCSV:        []byte(csv),
+		ReportFormatGzippedCSV: gzipped(csv),
+		ReportFormatTSV:        []byte("Campaign ID\tCampaign\tCost\n1\tBrand & Co\t1500000\n2\tGeneric\t0\n"),
+		ReportFormatXML:        []byte(testXMLReport),
+		ReportFormatGzippedXML: gzipped(testXMLReport),
+		// UTF-16LE with a byte order mark
+		ReportFormatCSVForExcel: utf16LE("\uFEFFCampaign ID\tCampaign\tCost\n1\tBrand & Co\t1500000\n2\tGeneric\t0\n"),
+	}
+	for format, report := range reports {
+		rows, err := NewReportReaderFormat(bytes.NewReader(report), format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows.Fields = []string{"CampaignId", "CampaignName", "Cost"}
+		got := []row{}
+		if err := rows.ReadAll(&got); err != nil {
+			t.Errorf("%s: %v", format, err)
+		} else if !reflect.DeepEqual(got, expected) {
+			t.Errorf("%s: got %+v", format, got)
+		}
+	}
+
+	if _, err := NewReportReaderFormat(strings.NewReader(""), "PDF"); err == nil {
+		t.Error("expected an unsupported format to be rejected")
+	}
+}
+
+func TestReportFormatAWQL(t *testing.T) {
+	client := &TestClient{res: &http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader(gzipped(testXMLReport))),
+	}}
+	rs := NewReportDownloadService(&Auth{Client: client})
+	res, err := rs.AWQL("SELECT CampaignId, CampaignName, Cost FROM CAMPAIGN_PERFORMANCE_REPORT DURING LAST_7_DAYS", ReportFormatGzippedXML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []map[string]string{
+		{"campaignID": "1", "campaign": "Brand & Co", "cost": "1500000"},
+		{"campaignID": "2", "campaign": "Generic", "cost": "0"},
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("got %v", res)
+	}
+}
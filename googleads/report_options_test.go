@@ -0,0 +1,145 @@
+package v201809
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// headerClient answers with body and keeps the headers of the last request.
+type headerClient struct {
+	body   string
+	header http.Header
+}
+
+func (c *headerClient) Do(req *http.Request) (*http.Response, error) {
+	c.header = req.Header.Clone()
+	return &http.Response{StatusCode: 200, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewBufferString(c.body))}, nil
+}
+
+const testSummaryReport = `"CAMPAIGN_PERFORMANCE_REPORT (Jan 1, 2018-Jan 31, 2018)"
+Campaign ID,Impressions,Cost
+1,10,1000000
+2,5,500000
+Total,15,1500000
+`
+
+type testTotalsRow struct {
+	CampaignId  int64   `report:"CampaignId"`
+	Impressions int64   `report:"Impressions"`
+	Cost        float64 `report:"Cost,micros"`
+}
+
+func TestReportOptionsHeaders(t *testing.T) {
+	client := &headerClient{body: "Campaign ID\n"}
+	rs := NewReportDownloadService(&Auth{Client: client})
+	query := "SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT DURING LAST_7_DAYS"
+	if _, err := rs.AWQL(query, ReportFormatCSV); err != nil {
+		t.Fatal(err)
+	}
+	for name, value := range map[string]string{"skipReportHeader": "true", "skipReportSummary": "true", "skipColumnHeader": "", "useRawEnumValues": ""} {
+		if got := client.header.Get(name); got != value {
+			t.Errorf("%s: got %q, expected %q by default", name, got, value)
+		}
+	}
+
+	_, err := rs.AWQL(query, ReportFormatCSV,
+		WithSkipReportHeader(false),
+		WithSkipColumnHeader(true),
+		WithIncludeZeroImpressions(true),
+		WithUseRawEnumValues(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, value := range map[string]string{"skipReportHeader": "false", "skipReportSummary": "true", "skipColumnHeader": "true", "includeZeroImpressions": "true", "useRawEnumValues": "true"} {
+		if got := client.header.Get(name); got != value {
+			t.Errorf("%s: got %q, expected %q", name, got, value)
+		}
+	}
+}
+
+func TestReportOptionsTotals(t *testing.T) {
+	client := &headerClient{body: testSummaryReport}
+	rs := NewReportDownloadService(&Auth{Client: client})
+	query := "SELECT CampaignId, Impressions, Cost FROM CAMPAIGN_PERFORMANCE_REPORT DURING 20180101,20180131"
+	rows, err := rs.StreamRows(query, ReportFormatCSV, WithSkipReportHeader(false), WithSkipReportSummary(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var totals testTotalsRow
+	if err := rows.Totals(&totals); err != ErrNoReportTotals {
+		t.Errorf("expected no totals before the end, got %v", err)
+	}
+	all := []testTotalsRow{}
+	if err := rows.ReadAll(&all); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(all, []testTotalsRow{{1, 10, 1}, {2, 5, 0.5}}) {
+		t.Errorf("got %+v", all)
+	}
+	if err := rows.Totals(&totals); err != nil {
+		t.Fatal(err)
+	}
+	if totals != (testTotalsRow{0, 15, 1.5}) {
+		t.Errorf("got totals %+v", totals)
+	}
+	if title, _ := rows.Title(); title != "CAMPAIGN_PERFORMANCE_REPORT (Jan 1, 2018-Jan 31, 2018)" {
+		t.Errorf("got title %q", title)
+	}
+
+	// the untyped API leaves the summary out
+	client.body = testSummaryReport
+	res, err := rs.AWQL(query, ReportFormatCSV, WithSkipReportHeader(false), WithSkipReportSummary(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows := res.([]map[string]string); len(rows) != 2 || rows[1]["Impressions"] != "5" {
+		t.Errorf("got %v", res)
+	}
+}
+
+func TestReportOptionsXMLTotals(t *testing.T) {
+	rows, err := NewReportReaderFormat(strings.NewReader(testXMLReport), ReportFormatXML, WithSkipReportSummary(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	type row struct {
+		CampaignId int64 `report:"campaignID"`
+	}
+	all := []row{}
+	if err := rows.ReadAll(&all); err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 {
+		t.Errorf("got %+v", all)
+	}
+	var totals row
+	if err := rows.Totals(&totals); err != ErrXMLReportTotals {
+		t.Errorf("expected totals of XML reports to be unsupported, got %v", err)
+	}
+}
+
+func TestReportOptionsSkipColumnHeader(t *testing.T) {
+	rows, err := NewReportReaderFormat(strings.NewReader("1,10,1000000\n"), ReportFormatCSV, WithSkipColumnHeader(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows.Fields = []string{"CampaignId", "Impressions", "Cost"}
+	var row testTotalsRow
+	if err := rows.Read(&row); err != nil {
+		t.Fatal(err)
+	}
+	if row != (testTotalsRow{1, 10, 1}) {
+		t.Errorf("got %+v", row)
+	}
+	if err := rows.Read(&row); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
@@ -0,0 +1,555 @@
+package v201809
+
+import (
+	"context"
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReportReader decodes the rows of a report into structs one at a time, so
+// reports of any size can be processed while they are downloaded.  It reads
+// all the download formats, gzipped ones being gunzipped on the fly.
+//
+// Columns are mapped onto the exported fields of a struct by their report
+// tag, or their name if they have none, matched without regard to case
+// against the field names of the query, see Fields, or the column header.
+// A tag of "-" skips the field.  The value is converted according to the
+// type of the field:
+//
+//   string and types of kind string, e.g. for enums, take the value as is
+//   integers and floats take numbers, "--" and empty values being 0
+//   floats take percentages as fractions, "12.5%" being 0.125 and
+//   "< 10%" being 0.1
+//   floats tagged micros, e.g. report:"Cost,micros", take micro amounts
+//   in currency units, integers take them as is
+//   time.Time takes dates like 2018-01-31, 2018-01 or 2018
+//   bool takes true and false
+//   encoding.TextUnmarshaler implementations take the value as is
+//
+// Example
+//
+//   type CampaignRow struct {
+//     CampaignId  int64     `report:"CampaignId"`
+//     Status      string    `report:"CampaignStatus"`
+//     Day         time.Time `report:"Date"`
+//     Impressions int64     `report:"Impressions"`
+//     Ctr         float64   `report:"Ctr"`
+//     Cost        float64   `report:"Cost,micros"`
+//   }
+//
+//   rows, err := reportDownloadService.StreamRows(query, gads.ReportFormatGzippedCSV)
+//   ...
+//   defer rows.Close()
+//   for {
+//     var row CampaignRow
+//     if err := rows.Read(&row); err == io.EOF {
+//       break
+//     } else if err != nil {
+//       return err
+//     }
+//     ...
+//   }
+//
+type ReportReader struct {
+	// Fields are the field names of the columns, in order, e.g. the
+	// selected fields of the query.  Columns not in Fields are matched on
+	// their header.
+	Fields []string
+
+	// CustomerId is the account of the report, set by ReportRunner.  It
+	// fills the fields tagged CustomerId when no column matches them.
+	CustomerId string
+
+	records reportRecords
+	closer  io.Closer
+	maps    map[reflect.Type]*rowMapping
+
+	// lines of CSV and TSV reports, as told by the download options
+	reportHeader bool
+	columnHeader bool
+	summary      bool
+	xml          bool // the summary of XML reports is not read
+
+	title   string
+	header  []string
+	row     int      // number of the last line read
+	pending []string // row read ahead to tell the summary
+	totals  []string
+	eof     bool
+}
+
+// ErrNoReportTotals is returned by ReportReader.Totals for reports without
+// summary, or before the last row was read.
+var ErrNoReportTotals = errors.New("gads: no report totals read")
+
+// ErrXMLReportTotals is returned by ReportReader.Totals for XML reports,
+// whose summary is not read.
+var ErrXMLReportTotals = errors.New("gads: totals of XML reports are not supported")
+
+// NewReportReader returns a reader of the CSV report r, which starts with
+// the column header.
+func NewReportReader(r io.Reader) *ReportReader {
+	rr, _ := NewReportReaderFormat(r, ReportFormatCSV)
+	return rr
+}
+
+// NewReportReaderFormat returns a reader of the report r downloaded in
+// format, one of the ReportFormat constants, with opts.  The options
+// controlling the report header, column header and summary must be those
+// of the download.
+func NewReportReaderFormat(r io.Reader, format string, opts ...CallOption) (*ReportReader, error) {
+	o := callOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return newReportReader(r, format, o)
+}
+
+func newReportReader(r io.Reader, format string, o callOptions) (*ReportReader, error) {
+	rr := &ReportReader{maps: map[reflect.Type]*rowMapping{}, columnHeader: true}
+	if c, ok := r.(io.Closer); ok {
+		rr.closer = c
+	}
+	var err error
+	rr.records, err = newReportRecords(r, format)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := rr.records.(*xmlRecords); ok {
+		rr.xml = true
+	}
+	if _, ok := rr.records.(*csv.Reader); ok {
+		headers := o.reportHeaders()
+		rr.reportHeader = headers["skipReportHeader"] != "true"
+		rr.columnHeader = headers["skipColumnHeader"] != "true"
+		rr.summary = headers["skipReportSummary"] != "true"
+	}
+	return rr, nil
+}
+
+// Header returns the column header of the report, reading it if need be.
+// It is empty if the download skipped it.
+func (r *ReportReader) Header() ([]string, error) {
+	if r.header != nil {
+		return r.header, nil
+	}
+	if r.reportHeader {
+		title, err := r.records.Read()
+		if err != nil {
+			return nil, err
+		}
+		r.title = strings.Join(title, ",")
+		r.row++
+	}
+	r.header = []string{}
+	if r.columnHeader {
+		header, err := r.records.Read()
+		if err != nil {
+			r.header = nil
+			return nil, err
+		}
+		r.header = append(r.header, header...)
+		r.row++
+	}
+	return r.header, nil
+}
+
+// Title returns the report header naming the report and its date range,
+// e.g. "CAMPAIGN_PERFORMANCE_REPORT (Jan 1, 2018-Jan 31, 2018)", if the
+// download kept it.
+func (r *ReportReader) Title() (string, error) {
+	_, err := r.Header()
+	return r.title, err
+}
+
+// next returns the next row of values, keeping the summary for Totals.
+func (r *ReportReader) next() ([]string, error) {
+	if _, err := r.Header(); err != nil {
+		return nil, err
+	}
+	if r.eof {
+		return nil, io.EOF
+	}
+	if !r.summary {
+		record, err := r.records.Read()
+		if err != nil {
+			return nil, err
+		}
+		r.row++
+		return record, nil
+	}
+
+	// the summary is the last row, so rows are read one ahead
+	if r.pending == nil {
+		record, err := r.records.Read()
+		if err != nil {
+			return nil, err
+		}
+		r.pending = append([]string(nil), record...)
+	}
+	record, err := r.records.Read()
+	if err == io.EOF {
+		r.totals, r.pending, r.eof = r.pending, nil, true
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, err
+	}
+	r.row++
+	current := r.pending
+	r.pending = append([]string(nil), record...)
+	return current, nil
+}
+
+// Totals decodes the summary row of the report into row, a pointer to a
+// struct, once Read returned io.EOF.  The label of the first column and
+// values like "--" are left as zero values.  Only CSV and TSV reports,
+// gzipped or not, have totals; Totals returns ErrXMLReportTotals for XML
+// ones.
+func (r *ReportReader) Totals(row interface{}) error {
+	if r.xml {
+		return ErrXMLReportTotals
+	}
+	if r.totals == nil {
+		return ErrNoReportTotals
+	}
+	rv := reflect.ValueOf(row)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("gads: report rows decode into pointers to structs, not %T", row)
+	}
+	m, err := r.mapping(rv.Elem().Type())
+	if err != nil {
+		return err
+	}
+	totals := append([]string{""}, r.totals[1:]...)
+	return m.decode(r.row+1, totals, rv.Elem())
+}
+
+// Read decodes the next row into row, which must be a pointer to a struct.
+// It returns io.EOF after the last row.
+func (r *ReportReader) Read(row interface{}) error {
+	rv := reflect.ValueOf(row)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("gads: report rows decode into pointers to structs, not %T", row)
+	}
+	m, err := r.mapping(rv.Elem().Type())
+	if err != nil {
+		return err
+	}
+	record, err := r.next()
+	if err != nil {
+		return err
+	}
+	return m.decode(r.row, record, rv.Elem())
+}
+
+// ReadAll decodes the remaining rows into rows, a pointer to a slice of
+// structs or of pointers to structs.
+func (r *ReportReader) ReadAll(rows interface{}) error {
+	rv := reflect.ValueOf(rows)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("gads: report rows decode into pointers to slices, not %T", rows)
+	}
+	slice := rv.Elem()
+	elem := slice.Type().Elem()
+	isPtr := elem.Kind() == reflect.Ptr
+	if isPtr {
+		elem = elem.Elem()
+	}
+	for {
+		v := reflect.New(elem)
+		if err := r.Read(v.Interface()); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if !isPtr {
+			v = v.Elem()
+		}
+		slice.Set(reflect.Append(slice, v))
+	}
+}
+
+// Close closes the underlying report stream, if it can be closed.
+func (r *ReportReader) Close() error {
+	if r.closer != nil {
+		return r.closer.Close()
+	}
+	return nil
+}
+
+// ReportDecodeError is returned by ReportReader for values that cannot be
+// converted to the type of their field.
+type ReportDecodeError struct {
+	Row    int // counting from 1, the report and column headers included
+	Column string
+	Value  string
+	Err    error
+}
+
+func (e *ReportDecodeError) Error() string {
+	return fmt.Sprintf("gads: report row %d, column %s: cannot decode %q: %v", e.Row, e.Column, e.Value, e.Err)
+}
+
+func (e *ReportDecodeError) Unwrap() error {
+	return e.Err
+}
+
+// rowMapping maps the columns of a report onto the fields of a struct.
+type rowMapping struct {
+	columns    []columnMapping
+	customerId string
+}
+
+type columnMapping struct {
+	index  int    // of the column, -1 for the customer id of the report
+	name   string // of the column, for errors
+	field  []int  // index of the struct field
+	micros bool
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// mapping returns the mapping of the columns onto t, building it on first
+// use.
+func (r *ReportReader) mapping(t reflect.Type) (*rowMapping, error) {
+	if m, ok := r.maps[t]; ok {
+		return m, nil
+	}
+	header, err := r.Header()
+	if err != nil {
+		return nil, err
+	}
+	column := func(name string) int {
+		for i, f := range r.Fields {
+			if strings.EqualFold(f, name) {
+				return i
+			}
+		}
+		for i, h := range header {
+			if strings.EqualFold(h, name) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	m := &rowMapping{customerId: r.CustomerId}
+	for _, f := range reflectFields(t) {
+		tag := f.Tag.Get("report")
+		if tag == "-" {
+			continue
+		}
+		name, opts := tag, ""
+		if i := strings.Index(tag, ","); i >= 0 {
+			name, opts = tag[:i], tag[i+1:]
+		}
+		if name == "" {
+			name = f.Name
+		}
+		i := column(name)
+		if i < 0 && r.CustomerId != "" && strings.EqualFold(name, "CustomerId") {
+			m.columns = append(m.columns, columnMapping{index: -1, name: name, field: f.Index})
+			continue
+		}
+		if i < 0 {
+			if tag != "" {
+				return nil, fmt.Errorf("gads: no report column for %s.%s, tagged %q", t.Name(), f.Name, name)
+			}
+			continue
+		}
+		m.columns = append(m.columns, columnMapping{
+			index:  i,
+			name:   name,
+			field:  f.Index,
+			micros: opts == "micros",
+		})
+	}
+	r.maps[t] = m
+	return m, nil
+}
+
+var (
+	reportFieldsMu sync.Mutex
+	reportFields   = map[reflect.Type][]reflect.StructField{}
+)
+
+// reflectFields returns the exported fields of struct t, including those
+// of embedded structs.
+func reflectFields(t reflect.Type) []reflect.StructField {
+	reportFieldsMu.Lock()
+	defer reportFieldsMu.Unlock()
+	if fields, ok := reportFields[t]; ok {
+		return fields
+	}
+	fields := structFields(t)
+	reportFields[t] = fields
+	return fields
+}
+
+func structFields(t reflect.Type) []reflect.StructField {
+	fields := []reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("report") == "" {
+			for _, ef := range structFields(f.Type) {
+				ef.Index = append([]int{i}, ef.Index...)
+				fields = append(fields, ef)
+			}
+			continue
+		}
+		if f.PkgPath == "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+func (m *rowMapping) decode(row int, record []string, v reflect.Value) error {
+	for _, c := range m.columns {
+		if c.index >= len(record) {
+			continue
+		}
+		value := m.customerId
+		if c.index >= 0 {
+			value = record[c.index]
+		}
+		if err := setReportValue(v.FieldByIndex(c.field), value, c.micros); err != nil {
+			return &ReportDecodeError{Row: row, Column: c.name, Value: value, Err: err}
+		}
+	}
+	return nil
+}
+
+// reportNumber returns value without the decorations of report numbers.
+// Values like "--" for unavailable metrics are empty.
+func reportNumber(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "--" {
+		return ""
+	}
+	return strings.Replace(value, ",", "", -1)
+}
+
+// parseReportFloat parses a number, a percentage or a bound like "< 10%".
+func parseReportFloat(value string) (float64, error) {
+	value = strings.TrimSpace(strings.TrimLeft(reportNumber(value), "<>"))
+	if value == "" {
+		return 0, nil
+	}
+	if strings.HasSuffix(value, "%") {
+		f, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(value, "%")), 64)
+		return f / 100, err
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+var reportDateLayouts = []string{"2006-01-02", "2006-01", "2006"}
+
+func setReportValue(v reflect.Value, value string, micros bool) error {
+	if v.Type() == reflect.TypeOf(time.Time{}) {
+		if value == "" || value == "--" {
+			v.Set(reflect.ValueOf(time.Time{}))
+			return nil
+		}
+		for _, layout := range reportDateLayouts {
+			if t, err := time.Parse(layout, value); err == nil {
+				v.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return fmt.Errorf("not a date")
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := reportNumber(value)
+		if n == "" {
+			v.SetInt(0)
+			return nil
+		}
+		i, err := strconv.ParseInt(n, 10, 64)
+		if err != nil {
+			return err
+		}
+		if v.OverflowInt(i) {
+			return fmt.Errorf("overflows %s", v.Type())
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n := reportNumber(value)
+		if n == "" {
+			v.SetUint(0)
+			return nil
+		}
+		i, err := strconv.ParseUint(n, 10, 64)
+		if err != nil {
+			return err
+		}
+		if v.OverflowUint(i) {
+			return fmt.Errorf("overflows %s", v.Type())
+		}
+		v.SetUint(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := parseReportFloat(value)
+		if err != nil {
+			return err
+		}
+		if micros {
+			f /= 1e6
+		}
+		if math.IsInf(f, 0) || v.OverflowFloat(f) {
+			return fmt.Errorf("overflows %s", v.Type())
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
+
+// StreamRows downloads the report of an AWQL query in format and returns a
+// reader decoding its rows as they arrive, see ReportReader.  The columns
+// are matched on the selected fields of the query.  The reader must be
+// closed.
+func (s *ReportDownloadService) StreamRows(awql string, format string, opts ...CallOption) (*ReportReader, error) {
+	return s.StreamRowsWithContext(context.Background(), awql, format, opts...)
+}
+
+// StreamRowsWithContext is the same as StreamRows with the addition of a
+// context, which governs reading the rows as well.
+func (s *ReportDownloadService) StreamRowsWithContext(ctx context.Context, awql string, format string, opts ...CallOption) (*ReportReader, error) {
+	ctx = withCallOptions(ctx, opts)
+	body, err := s.StreamAWQLWithContext(ctx, awql, format)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := newReportReader(body, format, callOptionsFrom(ctx))
+	if err != nil {
+		body.Close()
+		return nil, err
+	}
+	if q, err := ParseQuery(awql); err == nil {
+		rows.Fields = q.Fields
+	}
+	return rows, nil
+}
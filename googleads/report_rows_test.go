@@ -0,0 +1,101 @@
+package v201809
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type campaignStatus string
+
+type testReportIds struct {
+	CampaignId int64 `report:"CampaignId"`
+}
+
+type testReportRow struct {
+	testReportIds
+	Status      campaignStatus `report:"CampaignStatus"`
+	Day         time.Time      `report:"Date"`
+	Impressions int64          `report:"Impressions"`
+	Ctr         float64        `report:"Ctr"`
+	Cost        float64        `report:"Cost,micros"`
+	CostMicros  int64          `report:"Cost"`
+	Share       float64        `report:"SearchImpressionShare"`
+	Ignored     string         `report:"-"`
+}
+
+func TestReportReaderStreamRows(t *testing.T) {
+	report := "Campaign ID,Campaign state,Day,Impressions,CTR,Cost,Search Impr. share\n" +
+		"1,enabled,2018-01-31,\"1,234\",12.50%,1500000,< 10%\n" +
+		"2,paused,2018-02-01,0,0.00%,0,--\n"
+	client := &TestClient{res: &http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(report)),
+	}}
+	rs := NewReportDownloadService(&Auth{Client: client})
+	rows, err := rs.StreamRows("SELECT CampaignId, CampaignStatus, Date, Impressions, Ctr, Cost, SearchImpressionShare FROM CAMPAIGN_PERFORMANCE_REPORT DURING LAST_7_DAYS", ReportFormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var row testReportRow
+	if err := rows.Read(&row); err != nil {
+		t.Fatal(err)
+	}
+	expected := testReportRow{
+		testReportIds: testReportIds{CampaignId: 1},
+		Status:        "enabled",
+		Day:           time.Date(2018, 1, 31, 0, 0, 0, 0, time.UTC),
+		Impressions:   1234,
+		Ctr:           0.125,
+		Cost:          1.5,
+		CostMicros:    1500000,
+		Share:         0.1,
+	}
+	if row != expected {
+		t.Errorf("got %+v", row)
+	}
+
+	all := []*testReportRow{}
+	if err := rows.ReadAll(&all); err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 || all[0].CampaignId != 2 || all[0].Status != "paused" || all[0].Share != 0 {
+		t.Errorf("got %+v", all)
+	}
+	if err := rows.Read(&row); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestReportReaderHeader(t *testing.T) {
+	rows := NewReportReader(strings.NewReader("Campaign,Clicks\nBrand,twelve\n"))
+	var row struct {
+		Campaign string
+		Clicks   int
+	}
+	err := rows.Read(&row)
+	var de *ReportDecodeError
+	if !errors.As(err, &de) {
+		t.Fatalf("expected a ReportDecodeError, got %v", err)
+	}
+	if de.Row != 2 || de.Column != "Clicks" || de.Value != "twelve" {
+		t.Errorf("got %v", err)
+	}
+	if row.Campaign != "Brand" {
+		t.Errorf("expected the columns before the error to be decoded, got %+v", row)
+	}
+
+	var missing struct {
+		Cost float64 `report:"Cost"`
+	}
+	if err := rows.Read(&missing); err == nil {
+		t.Error("expected an error for a tag without column")
+	}
+}
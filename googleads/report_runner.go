@@ -0,0 +1,226 @@
+package v201809
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DefaultReportWorkers is the number of accounts a ReportRunner downloads
+// at once when Workers is not set.
+const DefaultReportWorkers = 4
+
+// ReportRunner downloads the report of an AWQL query for many accounts
+// concurrently.  Downloads go through the Auth of Service, so they share its
+// RateLimiter, and an account failing does not stop the others, see
+// ReportRunError.
+//
+// Example
+//
+//   runner := gads.NewReportRunner(&auth, query)
+//   customerIds, err := gads.NewManagedCustomerService(&auth).ClientCustomerIds(managerId)
+//   ...
+//   err = runner.RunMerged(ctx, customerIds, file)
+//   var runErr *gads.ReportRunError
+//   if errors.As(err, &runErr) {
+//     for _, e := range runErr.Errors {
+//       log.Printf("report of %s failed: %v", e.CustomerId, e.Err)
+//     }
+//   }
+//
+type ReportRunner struct {
+	Service *ReportDownloadService
+	Query   string
+	Format  string // ReportFormatCSV by default
+	Workers int    // DefaultReportWorkers by default
+
+	// Options apply to every download, e.g. WithIncludeZeroImpressions.
+	Options []CallOption
+}
+
+// NewReportRunner returns a runner of query for the accounts of auth.
+func NewReportRunner(auth *Auth, query string) *ReportRunner {
+	return &ReportRunner{Service: NewReportDownloadService(auth), Query: query}
+}
+
+// AccountError is the failure of the report of an account.
+type AccountError struct {
+	CustomerId string
+	Err        error
+}
+
+func (e *AccountError) Error() string {
+	return fmt.Sprintf("customer %s: %v", e.CustomerId, e.Err)
+}
+
+func (e *AccountError) Unwrap() error {
+	return e.Err
+}
+
+// ReportRunError is returned by the runs of a ReportRunner when the report
+// of some accounts failed, the reports of the others were processed.
+type ReportRunError struct {
+	Errors []*AccountError // in the order of the accounts
+}
+
+func (e *ReportRunError) Error() string {
+	msgs := []string{}
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("gads: report failed for %d accounts: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+func (r *ReportRunner) format() string {
+	if r.Format == "" {
+		return ReportFormatCSV
+	}
+	return r.Format
+}
+
+// each calls fn for every account with at most Workers calls at once, and
+// collects the errors.  Accounts not started when ctx is done fail with its
+// error.  Nothing is downloaded if reports of the format cannot be read.
+func (r *ReportRunner) each(ctx context.Context, customerIds []string, fn func(ctx context.Context, customerId string) error) error {
+	if err := checkReportFormat(r.format()); err != nil {
+		return err
+	}
+	workers := r.Workers
+	if workers <= 0 {
+		workers = DefaultReportWorkers
+	}
+	errs := make([]error, len(customerIds))
+	indexes := make(chan int)
+	wg := sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				ctx := withCallOptions(ctx, append(append([]CallOption(nil), r.Options...), WithCustomerId(customerIds[i])))
+				errs[i] = fn(ctx, customerIds[i])
+			}
+		}()
+	}
+	for i := range customerIds {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	runErr := &ReportRunError{}
+	for i, err := range errs {
+		if err != nil {
+			runErr.Errors = append(runErr.Errors, &AccountError{CustomerId: customerIds[i], Err: err})
+		}
+	}
+	if len(runErr.Errors) > 0 {
+		return runErr
+	}
+	return nil
+}
+
+// Run downloads the report of every account and passes its rows to fn,
+// which is called concurrently for different accounts.  The rows have
+// CustomerId set, see ReportReader, and are closed once fn returns.
+func (r *ReportRunner) Run(ctx context.Context, customerIds []string, fn func(customerId string, rows *ReportReader) error) error {
+	return r.each(ctx, customerIds, func(ctx context.Context, customerId string) error {
+		rows, err := r.Service.StreamRowsWithContext(ctx, r.Query, r.format())
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		rows.CustomerId = customerId
+		return fn(customerId, rows)
+	})
+}
+
+// RunMerged writes the rows of the reports of all accounts to w as a single
+// CSV report, whose first column is the CustomerId of the row.  Rows of
+// different accounts are interleaved as they are downloaded, and rows of
+// an account which failed half way through may have been written.
+func (r *ReportRunner) RunMerged(ctx context.Context, customerIds []string, w io.Writer) error {
+	mu := sync.Mutex{}
+	out := csv.NewWriter(w)
+	wroteHeader := false
+	var writeErr error
+	write := func(record []string) {
+		if writeErr == nil {
+			writeErr = out.Write(record)
+		}
+	}
+
+	err := r.Run(ctx, customerIds, func(customerId string, rows *ReportReader) error {
+		header, err := rows.Header()
+		if err != nil {
+			return err
+		}
+		if len(header) == 0 {
+			header = rows.Fields
+		}
+		mu.Lock()
+		if !wroteHeader {
+			write(append([]string{"CustomerId"}, header...))
+			wroteHeader = true
+		}
+		mu.Unlock()
+
+		for {
+			record, err := rows.next()
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			mu.Lock()
+			write(append([]string{customerId}, record...))
+			err = writeErr
+			mu.Unlock()
+			if err != nil {
+				return err
+			}
+		}
+	})
+	out.Flush()
+	if writeErr == nil {
+		writeErr = out.Error()
+	}
+	if writeErr != nil {
+		return writeErr
+	}
+	return err
+}
+
+// reportExtensions are the file extensions of the download formats.
+var reportExtensions = map[string]string{
+	ReportFormatCSV:         ".csv",
+	ReportFormatCSVForExcel: ".csv",
+	ReportFormatTSV:         ".tsv",
+	ReportFormatXML:         ".xml",
+	ReportFormatGzippedCSV:  ".csv.gz",
+	ReportFormatGzippedXML:  ".xml.gz",
+}
+
+// RunToDir writes the report of every account as downloaded to a file of
+// dir named after the account and the format, e.g. 1234567890.csv.gz.
+// Reports are spooled and verified as by DownloadAWQL, so a file only
+// exists once its report is complete.
+func (r *ReportRunner) RunToDir(ctx context.Context, customerIds []string, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	ext := reportExtensions[strings.ToUpper(r.format())]
+	return r.each(ctx, customerIds, func(ctx context.Context, customerId string) error {
+		_, err := r.Service.DownloadAWQLWithContext(ctx, r.Query, r.format(), filepath.Join(dir, customerId+ext))
+		return err
+	})
+}
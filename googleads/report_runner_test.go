@@ -0,0 +1,151 @@
+package v201809
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// accountsClient answers report downloads with the report of the account
+// of the request, accounts without one get an error.
+type accountsClient struct {
+	reports map[string]string
+
+	mu       sync.Mutex
+	accounts []string
+}
+
+func (c *accountsClient) Do(req *http.Request) (*http.Response, error) {
+	customerId := req.Header.Get("clientCustomerId")
+	c.mu.Lock()
+	c.accounts = append(c.accounts, customerId)
+	c.mu.Unlock()
+	report, ok := c.reports[customerId]
+	if !ok {
+		body := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><reportDownloadError><ApiError><type>AuthorizationError.USER_PERMISSION_DENIED</type></ApiError></reportDownloadError>`
+		return &http.Response{StatusCode: 400, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewBufferString(body))}, nil
+	}
+	return &http.Response{StatusCode: 200, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewBufferString(report))}, nil
+}
+
+func testRunner() (*ReportRunner, *accountsClient) {
+	client := &accountsClient{reports: map[string]string{
+		"111": "Campaign ID,Clicks\n1,10\n2,20\n",
+		"222": "Campaign ID,Clicks\n3,30\n",
+	}}
+	runner := NewReportRunner(&Auth{Client: client}, "SELECT CampaignId, Clicks FROM CAMPAIGN_PERFORMANCE_REPORT DURING YESTERDAY")
+	return runner, client
+}
+
+func TestReportRunner(t *testing.T) {
+	runner, client := testRunner()
+	type row struct {
+		CustomerId string `report:"CustomerId"`
+		CampaignId int64  `report:"CampaignId"`
+		Clicks     int64  `report:"Clicks"`
+	}
+	mu := sync.Mutex{}
+	rows := []row{}
+	err := runner.Run(context.Background(), []string{"111", "333", "222"}, func(customerId string, reader *ReportReader) error {
+		account := []row{}
+		if err := reader.ReadAll(&account); err != nil {
+			return err
+		}
+		mu.Lock()
+		rows = append(rows, account...)
+		mu.Unlock()
+		return nil
+	})
+
+	var runErr *ReportRunError
+	if !errors.As(err, &runErr) || len(runErr.Errors) != 1 || runErr.Errors[0].CustomerId != "333" || !IsAuthError(runErr.Errors[0]) {
+		t.Fatalf("expected the report of 333 to fail alone, got %v", err)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].CampaignId < rows[j].CampaignId })
+	expected := []row{{"111", 1, 10}, {"111", 2, 20}, {"222", 3, 30}}
+	if len(rows) != len(expected) {
+		t.Fatalf("got %+v", rows)
+	}
+	for i := range rows {
+		if rows[i] != expected[i] {
+			t.Errorf("got %+v", rows)
+		}
+	}
+	if len(client.accounts) != 3 {
+		t.Errorf("expected a download per account, got %v", client.accounts)
+	}
+}
+
+func TestReportRunnerMerged(t *testing.T) {
+	runner, _ := testRunner()
+	runner.Workers = 1
+	var b bytes.Buffer
+	if err := runner.RunMerged(context.Background(), []string{"111", "222"}, &b); err != nil {
+		t.Fatal(err)
+	}
+	expected := "CustomerId,Campaign ID,Clicks\n111,1,10\n111,2,20\n222,3,30\n"
+	if b.String() != expected {
+		t.Errorf("got\n%s", b.String())
+	}
+}
+
+func TestReportRunnerToDir(t *testing.T) {
+	runner, _ := testRunner()
+	dir, err := ioutil.TempDir("", "gads-reports")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = runner.RunToDir(context.Background(), []string{"111", "222", "333"}, dir)
+	if err == nil {
+		t.Error("expected the report of 333 to fail")
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*"))
+	for i := range files {
+		files[i] = filepath.Base(files[i])
+	}
+	if strings.Join(files, " ") != "111.csv 222.csv" {
+		t.Errorf("got files %v", files)
+	}
+	if data, _ := ioutil.ReadFile(filepath.Join(dir, "222.csv")); string(data) != "Campaign ID,Clicks\n3,30\n" {
+		t.Errorf("got %q", data)
+	}
+}
+
+func TestClientCustomerIds(t *testing.T) {
+	// 100 manages 200 and the client 101, 200 manages the clients 201 and
+	// 202, the link to 203 is inactive
+	client := &countingClient{status: 200, body: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><getResponse xmlns="https://adwords.google.com/api/adwords/mcm/v201809"><rval>
+<totalNumEntries>6</totalNumEntries>
+<entries><customerId>100</customerId><canManageClients>true</canManageClients></entries>
+<entries><customerId>101</customerId></entries>
+<entries><customerId>200</customerId><canManageClients>true</canManageClients></entries>
+<entries><customerId>201</customerId></entries>
+<entries><customerId>202</customerId></entries>
+<entries><customerId>203</customerId></entries>
+<links><managerCustomerId>100</managerCustomerId><clientCustomerId>200</clientCustomerId><linkStatus>ACTIVE</linkStatus></links>
+<links><managerCustomerId>100</managerCustomerId><clientCustomerId>101</clientCustomerId><linkStatus>ACTIVE</linkStatus></links>
+<links><managerCustomerId>200</managerCustomerId><clientCustomerId>201</clientCustomerId><linkStatus>ACTIVE</linkStatus></links>
+<links><managerCustomerId>200</managerCustomerId><clientCustomerId>202</clientCustomerId><linkStatus>ACTIVE</linkStatus></links>
+<links><managerCustomerId>200</managerCustomerId><clientCustomerId>203</clientCustomerId><linkStatus>INACTIVE</linkStatus></links>
+</rval></getResponse></soap:Body></soap:Envelope>`}
+	ids, err := NewManagedCustomerService(&Auth{Client: client}).ClientCustomerIds("100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(ids, " ") != "101 201 202" {
+		t.Errorf("got %v", ids)
+	}
+	if client.calls != 1 {
+		t.Errorf("expected a single page, got %d requests", client.calls)
+	}
+}
@@ -0,0 +1,171 @@
+package v201809
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// ReportFile describes a report downloaded to a file.
+type ReportFile struct {
+	Path   string
+	Size   int64  // bytes as downloaded, compressed or not
+	SHA256 string // hex encoded checksum of the file
+	Rows   int64  // rows of values, without headers and summary
+}
+
+// IncompleteReportError is returned when a downloaded report is cut short,
+// e.g. a connection dropped mid-report.  Such downloads are retried like
+// io.ErrUnexpectedEOF.
+type IncompleteReportError struct {
+	File string
+	Err  error
+}
+
+func (e *IncompleteReportError) Error() string {
+	return fmt.Sprintf("gads: incomplete report %s: %v", e.File, e.Err)
+}
+
+func (e *IncompleteReportError) Unwrap() error {
+	return e.Err
+}
+
+func (e *IncompleteReportError) Is(target error) bool {
+	return target == io.ErrUnexpectedEOF
+}
+
+// Download downloads the report of reportDefinition to file.  See
+// DownloadAWQL.
+func (s *ReportDownloadService) Download(reportDefinition ReportDefinition, file string, opts ...CallOption) (*ReportFile, error) {
+	return s.DownloadWithContext(context.Background(), reportDefinition, file, opts...)
+}
+
+// DownloadWithContext is the same as Download with the addition of a context.
+func (s *ReportDownloadService) DownloadWithContext(ctx context.Context, reportDefinition ReportDefinition, file string, opts ...CallOption) (*ReportFile, error) {
+	ctx = withCallOptions(ctx, opts)
+	form, err := definitionForm(reportDefinition)
+	if err != nil {
+		return nil, err
+	}
+	return s.download(ctx, form, reportDefinition.DownloadFormat, file)
+}
+
+// DownloadAWQL downloads the report of the AWQL query awql in format to
+// file.  The report is spooled to a temporary file next to file and
+// verified, its length against the response and its rows by parsing it,
+// before being renamed to file, so file only exists once complete.
+// Transient errors, including incomplete reports, are retried according to
+// the RetryPolicy of Auth.
+func (s *ReportDownloadService) DownloadAWQL(awql string, format string, file string, opts ...CallOption) (*ReportFile, error) {
+	return s.DownloadAWQLWithContext(context.Background(), awql, format, file, opts...)
+}
+
+// DownloadAWQLWithContext is the same as DownloadAWQL with the addition of
+// a context.
+func (s *ReportDownloadService) DownloadAWQLWithContext(ctx context.Context, awql string, format string, file string, opts ...CallOption) (*ReportFile, error) {
+	ctx = withCallOptions(ctx, opts)
+	form := url.Values{}
+	form.Add("__rdquery", awql)
+	form.Add("__fmt", format)
+	return s.download(ctx, form, format, file)
+}
+
+func (s *ReportDownloadService) download(ctx context.Context, form url.Values, format string, file string) (report *ReportFile, err error) {
+	// reports are verified by reading them, so they must be readable
+	if err := checkReportFormat(format); err != nil {
+		return nil, err
+	}
+	attempts := 0
+	err = s.withRetry(ctx, func() (bool, error) {
+		if attempts++; attempts > 1 {
+			stat.retry("ReportDownloadService", "download")
+		}
+		res, err := s.makeRequestOnce(ctx, form)
+		if err != nil {
+			return true, err
+		}
+		defer res.Body.Close()
+		report, err = spoolReport(res, format, file, callOptionsFrom(ctx))
+		return true, err
+	})
+	return report, err
+}
+
+// spoolReport writes the report of res to a temporary file next to file,
+// verifies it and renames it to file.
+func spoolReport(res *http.Response, format string, file string, o callOptions) (*ReportFile, error) {
+	f, err := ioutil.TempFile(filepath.Dir(file), filepath.Base(file)+".tmp")
+	if err != nil {
+		return nil, err
+	}
+	report, err := verifyReport(f, file, res, format, o)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), file)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return nil, err
+	}
+	report.Path = file
+	return report, nil
+}
+
+// verifyReport copies the report of res to f, spooling file, checks its
+// length and counts its rows, which also checks gzip checksums and the
+// syntax of the report.
+func verifyReport(f *os.File, file string, res *http.Response, format string, o callOptions) (*ReportFile, error) {
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(f, hash), res.Body)
+	if err != nil {
+		return nil, incompleteReport(file, err)
+	}
+	if res.ContentLength > 0 && size != res.ContentLength {
+		return nil, &IncompleteReportError{
+			File: file,
+			Err:  fmt.Errorf("got %d of %d bytes", size, res.ContentLength),
+		}
+	}
+	if err := f.Sync(); err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	report := &ReportFile{Size: size, SHA256: hex.EncodeToString(hash.Sum(nil))}
+	rows, err := newReportReader(ioutil.NopCloser(f), format, o)
+	if err != nil {
+		return nil, incompleteReport(file, err)
+	}
+	for {
+		_, err := rows.next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, incompleteReport(file, err)
+		}
+		report.Rows++
+	}
+	return report, nil
+}
+
+// incompleteReport wraps err in an IncompleteReportError if it tells that
+// the report of file was cut short.  Other errors, e.g. of a malformed
+// report, are not worth downloading it again and are returned as is.
+func incompleteReport(file string, err error) error {
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return &IncompleteReportError{File: file, Err: err}
+	}
+	return err
+}
@@ -0,0 +1,224 @@
+package v201809
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// sequenceClient answers requests with its responses in turn, repeating
+// the last one.
+type sequenceClient struct {
+	responses []func() *http.Response
+	calls     int
+}
+
+func (c *sequenceClient) Do(req *http.Request) (*http.Response, error) {
+	i := c.calls
+	if i >= len(c.responses) {
+		i = len(c.responses) - 1
+	}
+	c.calls++
+	return c.responses[i](), nil
+}
+
+func reportResponse(status int, body string, length int64) func() *http.Response {
+	return func() *http.Response {
+		return &http.Response{
+			StatusCode:    status,
+			Header:        http.Header{},
+			ContentLength: length,
+			Body:          ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+	}
+}
+
+func reportErrorResponse(errorType string) func() *http.Response {
+	body := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><reportDownloadError><ApiError><type>` + errorType + `</type></ApiError></reportDownloadError>`
+	return reportResponse(400, body, -1)
+}
+
+func spoolService(responses ...func() *http.Response) (*ReportDownloadService, *sequenceClient) {
+	client := &sequenceClient{responses: responses}
+	auth := &Auth{Client: client, RetryPolicy: ExponentialBackoff{MaxRetries: 3, BaseDelay: time.Millisecond}}
+	return NewReportDownloadService(auth), client
+}
+
+// assertSpoolDir checks that dir only holds files, no temporary files.
+func assertSpoolDir(t *testing.T, dir string, files ...string) {
+	t.Helper()
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, info := range infos {
+		names = append(names, info.Name())
+	}
+	if len(names) != len(files) {
+		t.Fatalf("expected %q in %s, got %q", files, dir, names)
+	}
+	for i := range files {
+		if names[i] != files[i] {
+			t.Errorf("expected %q in %s, got %q", files, dir, names)
+		}
+	}
+}
+
+func TestReportSpoolRetriesTruncated(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gads")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	report := "Campaign ID,Clicks\n1,10\n2,20\n"
+	s, client := spoolService(
+		reportResponse(200, report[:20], int64(len(report))),
+		reportErrorResponse("RateExceededError.RATE_EXCEEDED"),
+		reportResponse(200, report, int64(len(report))),
+	)
+	file := filepath.Join(dir, "report.csv")
+	got, err := s.DownloadAWQL("SELECT CampaignId, Clicks FROM CAMPAIGN_PERFORMANCE_REPORT", ReportFormatCSV, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.calls != 3 {
+		t.Errorf("expected 3 calls, got %d", client.calls)
+	}
+	sum := sha256.Sum256([]byte(report))
+	expected := ReportFile{
+		Path:   file,
+		Size:   int64(len(report)),
+		SHA256: hex.EncodeToString(sum[:]),
+		Rows:   2,
+	}
+	if *got != expected {
+		t.Errorf("got %+v", got)
+	}
+	body, err := ioutil.ReadFile(file)
+	if err != nil || string(body) != report {
+		t.Errorf("got %q, %v", body, err)
+	}
+	assertSpoolDir(t, dir, "report.csv")
+}
+
+func TestReportSpoolPermanentError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gads")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s, client := spoolService(reportErrorResponse("AuthorizationError.USER_PERMISSION_DENIED"))
+	_, err = s.DownloadAWQL("SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT", ReportFormatCSV, filepath.Join(dir, "report.csv"))
+	if !IsAuthError(err) {
+		t.Errorf("expected an authorization error, got %v", err)
+	}
+	if client.calls != 1 {
+		t.Errorf("expected a single call, got %d", client.calls)
+	}
+	assertSpoolDir(t, dir)
+}
+
+func TestReportSpoolTruncatedGzip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gads")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	report := gzipped("Campaign ID,Clicks\n1,10\n")
+	s, client := spoolService(reportResponse(200, string(report[:len(report)-4]), -1))
+	_, err = s.DownloadAWQL("SELECT CampaignId, Clicks FROM CAMPAIGN_PERFORMANCE_REPORT", ReportFormatGzippedCSV, filepath.Join(dir, "report.csv.gz"))
+	var incomplete *IncompleteReportError
+	if !errors.As(err, &incomplete) {
+		t.Fatalf("expected an IncompleteReportError, got %v", err)
+	}
+	if client.calls != 4 {
+		t.Errorf("expected the download to be retried 3 times, got %d calls", client.calls)
+	}
+	assertSpoolDir(t, dir)
+}
+
+func TestReportSpoolCorruptGzip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gads")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	corrupt := gzipped("Campaign ID,Clicks\n1,10\n")
+	corrupt[len(corrupt)-6] ^= 0xff // the CRC-32 of the trailer
+
+	// a complete but malformed report is not downloaded again
+	s, client := spoolService(reportResponse(200, string(corrupt), -1))
+	_, err = s.DownloadAWQL("SELECT CampaignId, Clicks FROM CAMPAIGN_PERFORMANCE_REPORT", ReportFormatGzippedCSV, filepath.Join(dir, "report.csv.gz"))
+	if !errors.Is(err, gzip.ErrChecksum) {
+		t.Fatalf("expected a checksum error, got %v", err)
+	}
+	var incomplete *IncompleteReportError
+	if errors.As(err, &incomplete) {
+		t.Errorf("expected the report not to be reported incomplete, got %v", err)
+	}
+	if client.calls != 1 {
+		t.Errorf("expected a single call, got %d", client.calls)
+	}
+	assertSpoolDir(t, dir)
+}
+
+func TestReportStreamRetries(t *testing.T) {
+	s, client := spoolService(
+		reportErrorResponse("ReportDownloadError.ERROR_GETTING_RESPONSE_FROM_BACKEND"),
+		reportResponse(503, "<html>Service Unavailable</html>", -1),
+		reportResponse(200, "Campaign ID\n1\n", -1),
+	)
+	body, err := s.StreamAWQL("SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT", ReportFormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	if client.calls != 3 {
+		t.Errorf("expected 3 calls, got %d", client.calls)
+	}
+}
+
+func TestReportSpoolFormats(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gads")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	report := string(utf16LE("\uFEFFCampaign ID\tClicks\n1\t10\n2\t20\n"))
+	s, client := spoolService(reportResponse(200, report, int64(len(report))))
+	got, err := s.DownloadAWQL("SELECT CampaignId, Clicks FROM CAMPAIGN_PERFORMANCE_REPORT", ReportFormatCSVForExcel, filepath.Join(dir, "report.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Rows != 2 {
+		t.Errorf("got %d rows, expected 2", got.Rows)
+	}
+
+	// unreadable formats are not downloaded at all
+	if _, err := s.DownloadAWQL("SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT", "PDF", filepath.Join(dir, "report.pdf")); err == nil {
+		t.Error("expected an unsupported format to be rejected")
+	}
+	runner := &ReportRunner{Service: s, Query: "SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT", Format: "PDF"}
+	if err := runner.RunToDir(context.Background(), []string{"111"}, dir); err == nil {
+		t.Error("expected an unsupported format to be rejected")
+	}
+	if client.calls != 1 {
+		t.Errorf("got %d calls, expected 1", client.calls)
+	}
+	assertSpoolDir(t, dir, "report.csv")
+}
@@ -0,0 +1,45 @@
+package v201809
+
+import "context"
+
+// ResponseHeader is the metadata returned with every SOAP response.
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/CampaignService.SoapResponseHeader
+//
+type ResponseHeader struct {
+	RequestId    string `xml:"requestId"`
+	ServiceName  string `xml:"serviceName"`
+	MethodName   string `xml:"methodName"`
+	Operations   int64  `xml:"operations"`
+	ResponseTime int64  `xml:"responseTime"` // milliseconds
+}
+
+type responseHeaderKey struct{}
+
+// WithResponseHeader returns a context that makes the WithContext service
+// methods store the header of their response in h.  When a call is retried
+// h holds the header of the last attempt.
+//
+// Example
+//
+//   var header gads.ResponseHeader
+//   campaigns, _, err := campaignService.GetWithContext(
+//     gads.WithResponseHeader(ctx, &header),
+//     selector,
+//   )
+//   log.Printf("requestId=%s operations=%d", header.RequestId, header.Operations)
+//
+func WithResponseHeader(ctx context.Context, h *ResponseHeader) context.Context {
+	return context.WithValue(ctx, responseHeaderKey{}, h)
+}
+
+func (a *Auth) handleResponseHeader(ctx context.Context, h ResponseHeader) {
+	if out, ok := ctx.Value(responseHeaderKey{}).(*ResponseHeader); ok && out != nil {
+		*out = h
+	}
+	if a.ResponseHeaderHandler != nil {
+		a.ResponseHeaderHandler(h)
+	}
+}
@@ -0,0 +1,56 @@
+package v201809
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const testResponseHeader = `<soap:Header><ResponseHeader xmlns="https://adwords.google.com/api/adwords/cm/v201809"><requestId>0005a1b2c3</requestId><serviceName>CampaignService</serviceName><methodName>get</methodName><operations>3</operations><responseTime>42</responseTime></ResponseHeader></soap:Header>`
+
+func TestResponseHeader(t *testing.T) {
+	client := &countingClient{status: 200, body: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">` + testResponseHeader + `<soap:Body><getResponse xmlns="https://adwords.google.com/api/adwords/cm/v201809"><rval><totalNumEntries>0</totalNumEntries></rval></getResponse></soap:Body></soap:Envelope>`}
+	var handled []ResponseHeader
+	auth := &Auth{
+		Client:                client,
+		ResponseHeaderHandler: func(h ResponseHeader) { handled = append(handled, h) },
+	}
+
+	var header ResponseHeader
+	ctx := WithResponseHeader(context.Background(), &header)
+	if _, _, err := NewCampaignService(auth).GetWithContext(ctx, Selector{}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := ResponseHeader{
+		RequestId:    "0005a1b2c3",
+		ServiceName:  "CampaignService",
+		MethodName:   "get",
+		Operations:   3,
+		ResponseTime: 42,
+	}
+	if header != expected {
+		t.Errorf("got %+v, expected %+v", header, expected)
+	}
+	if len(handled) != 1 || handled[0] != expected {
+		t.Errorf("got %+v from the handler, expected %+v", handled, expected)
+	}
+}
+
+func TestErrorRequestId(t *testing.T) {
+	fault := testFault("RequiredError", "REQUIRED", "")
+	fault = strings.Replace(fault, "<soap:Body>", testResponseHeader+"<soap:Body>", 1)
+	for _, body := range []string{
+		fault,
+		strings.Replace(fault, "RequiredError", "AuthenticationError", -1),
+	} {
+		auth := &Auth{Client: &countingClient{status: 500, body: body}}
+		_, _, err := NewCampaignService(auth).Get(Selector{})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if id := ErrorRequestId(err); id != "0005a1b2c3" {
+			t.Errorf("got requestId %q from %T", id, err)
+		}
+	}
+}
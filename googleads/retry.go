@@ -0,0 +1,180 @@
+package v201809
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// RetryPolicy decides whether a failed request is sent again and how long
+// to wait before doing so.
+type RetryPolicy interface {
+	// Retry is called after the attempt'th failed attempt (starting at 1)
+	// with the error it returned.  It returns the delay before the next
+	// attempt, or false if the error should be returned to the caller.
+	Retry(attempt int, err error) (time.Duration, bool)
+}
+
+// ExponentialBackoff retries transient errors with exponentially growing,
+// jittered delays.  A RateExceededError asking for a longer pause via
+// retryAfterSeconds takes precedence over the computed delay.
+type ExponentialBackoff struct {
+	MaxRetries int           // retries after the first attempt, 0 disables retrying
+	BaseDelay  time.Duration // delay before the first retry
+	MaxDelay   time.Duration // upper bound of the computed delay, 0 for none
+	Jitter     float64       // fraction of the delay that is randomized, 0 to 1
+
+	// Retryable classifies errors, transient faults are retried when nil.
+	Retryable func(error) bool
+}
+
+var (
+	// DefaultRetryPolicy is used by Auth when no RetryPolicy is set.
+	DefaultRetryPolicy RetryPolicy = ExponentialBackoff{
+		MaxRetries: 4,
+		BaseDelay:  2 * time.Second,
+		MaxDelay:   time.Minute,
+		Jitter:     0.2,
+	}
+
+	// NoRetry returns every error to the caller on the first failure.
+	NoRetry RetryPolicy = ExponentialBackoff{}
+)
+
+func (b ExponentialBackoff) Retry(attempt int, err error) (time.Duration, bool) {
+	if attempt > b.MaxRetries {
+		return 0, false
+	}
+	retryable := b.Retryable
+	if retryable == nil {
+		retryable = isTransientError
+	}
+	if !retryable(err) {
+		return 0, false
+	}
+
+	delay := b.BaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if b.MaxDelay > 0 && delay >= b.MaxDelay {
+			break
+		}
+	}
+	if b.MaxDelay > 0 && delay > b.MaxDelay {
+		delay = b.MaxDelay
+	}
+	if b.Jitter > 0 {
+		spread := float64(delay) * b.Jitter
+		delay = time.Duration(float64(delay) - spread + rand.Float64()*2*spread)
+	}
+	if after := retryAfter(err); after > delay {
+		delay = after
+	}
+	return delay, true
+}
+
+// HTTPError is returned when the API answers with an error status but no
+// SOAP fault, as load balancers and proxies do.
+type HTTPError struct {
+	StatusCode int
+	Body       []byte
+	RequestId  string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// apiFaults returns the decoded ApiExceptionFaults carried by err, if any.
+func apiFaults(err error) []ApiExceptionFault {
+	switch e := err.(type) {
+	case *ErrorsType:
+		return e.ApiExceptionFaults
+	case ErrorsType:
+		return e.ApiExceptionFaults
+	case Error:
+		if e.OrigErr() != nil {
+			return apiFaults(e.OrigErr())
+		}
+	}
+	return nil
+}
+
+// transientReportErrors are the report download errors worth retrying,
+// besides rate limiting, internal and database errors.
+var transientReportErrors = map[string]bool{
+	"ReportDownloadError.ERROR_GETTING_RESPONSE_FROM_BACKEND": true,
+}
+
+// isTransientError reports whether err is likely to go away by itself:
+// rate limiting, internal and database errors on Google's side, HTTP 429
+// and 5xx responses, timeouts and dropped connections.
+func isTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+	// client.Do wraps every failure in a *url.Error, which is a net.Error,
+	// failed token refreshes included
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) {
+		return false
+	}
+	var reportErr ApiError
+	if errors.As(err, &reportErr) {
+		switch reportErr.ErrorType() {
+		case "RateExceededError", "InternalApiError", "DatabaseError":
+			return true
+		}
+		return transientReportErrors[reportErr.Type]
+	}
+	for _, aef := range apiFaults(err) {
+		switch aef.ErrorsType {
+		case "RateExceededError", "InternalApiError", "DatabaseError":
+			return true
+		}
+		for _, e := range aef.Errors {
+			if _, ok := e.(RateExceededError); ok {
+				return true
+			}
+		}
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE)
+}
+
+// retryAfter returns the pause requested by a RateExceededError in err.
+func retryAfter(err error) time.Duration {
+	var after time.Duration
+	for _, aef := range apiFaults(err) {
+		for _, e := range aef.Errors {
+			if ree, ok := e.(RateExceededError); ok {
+				if d := time.Duration(ree.RetryAfterSeconds) * time.Second; d > after {
+					after = d
+				}
+			}
+		}
+	}
+	return after
+}
@@ -0,0 +1,164 @@
+package v201809
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// testFault builds a SOAP fault response carrying a single ApiError.
+func testFault(errorType, reason, extra string) string {
+	return fmt.Sprintf(`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><soap:Fault><faultcode>soap:Server</faultcode><faultstring>[%[1]s.%[2]s]</faultstring><detail><ApiExceptionFault xmlns="https://adwords.google.com/api/adwords/cm/v201809"><message>[%[1]s.%[2]s]</message><ApplicationException.Type>ApiException</ApplicationException.Type><errors xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="%[1]s"><fieldPath></fieldPath><trigger></trigger><errorString>%[1]s.%[2]s</errorString><ApiError.Type>%[1]s</ApiError.Type><reason>%[2]s</reason>%[3]s</errors></ApiExceptionFault></detail></soap:Fault></soap:Body></soap:Envelope>`, errorType, reason, extra)
+}
+
+// countingClient answers every request with the same status and body.
+type countingClient struct {
+	calls  int
+	status int
+	body   string
+}
+
+func (c *countingClient) Do(req *http.Request) (*http.Response, error) {
+	c.calls++
+	return &http.Response{
+		Body:       ioutil.NopCloser(bytes.NewBufferString(c.body)),
+		StatusCode: c.status,
+		Header:     http.Header{},
+	}, nil
+}
+
+func TestRetryTransientFaults(t *testing.T) {
+	policy := ExponentialBackoff{MaxRetries: 2, BaseDelay: time.Millisecond}
+	client := &countingClient{status: 500, body: testFault("InternalApiError", "UNEXPECTED_INTERNAL_API_ERROR", "")}
+	auth := &Auth{Client: client, RetryPolicy: policy}
+
+	_, _, err := NewCampaignService(auth).Get(Selector{})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if client.calls != 3 {
+		t.Errorf("got %d calls, expected 3", client.calls)
+	}
+}
+
+func TestRetrySkipsPermanentFaults(t *testing.T) {
+	policy := ExponentialBackoff{MaxRetries: 2, BaseDelay: time.Millisecond}
+	for _, body := range []string{
+		testFault("AuthenticationError", "GOOGLE_ACCOUNT_COOKIE_INVALID", ""),
+		testFault("RequiredError", "REQUIRED", ""),
+	} {
+		client := &countingClient{status: 500, body: body}
+		auth := &Auth{Client: client, RetryPolicy: policy}
+
+		if _, _, err := NewCampaignService(auth).Get(Selector{}); err == nil {
+			t.Fatal("expected an error")
+		}
+		if client.calls != 1 {
+			t.Errorf("got %d calls, expected 1", client.calls)
+		}
+	}
+}
+
+func TestRetryHTTPStatus(t *testing.T) {
+	policy := ExponentialBackoff{MaxRetries: 1, BaseDelay: time.Millisecond}
+	client := &countingClient{status: 503, body: "<html>Service Unavailable</html>"}
+	auth := &Auth{Client: client, RetryPolicy: policy}
+
+	_, _, err := NewCampaignService(auth).Get(Selector{})
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != 503 {
+		t.Fatalf("got %v, expected a 503 HTTPError", err)
+	}
+	if client.calls != 2 {
+		t.Errorf("got %d calls, expected 2", client.calls)
+	}
+}
+
+func TestRetryHonorsRetryAfterSeconds(t *testing.T) {
+	client := &countingClient{status: 500, body: testFault("RateExceededError", "RATE_EXCEEDED",
+		"<rateName>OperationsByMinute</rateName><rateScope>ACCOUNT</rateScope><retryAfterSeconds>30</retryAfterSeconds>")}
+	auth := &Auth{Client: client, RetryPolicy: NoRetry}
+
+	_, _, err := NewCampaignService(auth).Get(Selector{})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	policy := ExponentialBackoff{MaxRetries: 1, BaseDelay: time.Second, Jitter: 0.5}
+	delay, ok := policy.Retry(1, err)
+	if !ok {
+		t.Fatal("expected RateExceededError to be retried")
+	}
+	if delay != 30*time.Second {
+		t.Errorf("got delay %s, expected 30s", delay)
+	}
+	if _, ok := policy.Retry(2, err); ok {
+		t.Errorf("expected retries to be exhausted")
+	}
+}
+
+// countingTokenSource counts the tokens asked for and fails with err.
+type countingTokenSource struct {
+	calls int
+	err   error
+}
+
+func (s *countingTokenSource) Token() (*oauth2.Token, error) {
+	s.calls++
+	return nil, s.err
+}
+
+func TestRetrySkipsTokenFailures(t *testing.T) {
+	policy := ExponentialBackoff{MaxRetries: 2, BaseDelay: time.Millisecond}
+	for _, tokenErr := range []error{
+		errors.New("oauth2: token expired and refresh token is not set"),
+		&oauth2.RetrieveError{Response: &http.Response{StatusCode: 400}, Body: []byte(`{"error":"invalid_grant"}`)},
+	} {
+		source := &countingTokenSource{err: tokenErr}
+		client := &http.Client{Transport: &oauth2.Transport{Source: source, Base: http.DefaultTransport}}
+		auth := &Auth{Client: client, RetryPolicy: policy}
+
+		_, _, err := NewCampaignService(auth).Get(Selector{})
+		if !errors.Is(err, tokenErr) {
+			t.Fatalf("got %v, expected %v", err, tokenErr)
+		}
+		if source.calls != 1 {
+			t.Errorf("%v: got %d attempts, expected 1", tokenErr, source.calls)
+		}
+		if IsRetryable(err) {
+			t.Errorf("%v: expected not to be retryable", tokenErr)
+		}
+	}
+}
+
+// failingClient fails every request with err.
+type failingClient struct {
+	calls int
+	err   error
+}
+
+func (c *failingClient) Do(req *http.Request) (*http.Response, error) {
+	c.calls++
+	return nil, c.err
+}
+
+func TestRetryConnectionErrors(t *testing.T) {
+	policy := ExponentialBackoff{MaxRetries: 1, BaseDelay: time.Millisecond}
+	client := &failingClient{err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}}
+	auth := &Auth{Client: client, RetryPolicy: policy}
+
+	if _, _, err := NewCampaignService(auth).Get(Selector{}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if client.calls != 2 {
+		t.Errorf("got %d calls, expected 2", client.calls)
+	}
+}
@@ -0,0 +1,268 @@
+package v201809
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Operator is the operator of a Predicate.
+type Operator string
+
+const (
+	OperatorEquals                   Operator = "EQUALS"
+	OperatorNotEquals                Operator = "NOT_EQUALS"
+	OperatorIn                       Operator = "IN"
+	OperatorNotIn                    Operator = "NOT_IN"
+	OperatorGreaterThan              Operator = "GREATER_THAN"
+	OperatorGreaterThanEquals        Operator = "GREATER_THAN_EQUALS"
+	OperatorLessThan                 Operator = "LESS_THAN"
+	OperatorLessThanEquals           Operator = "LESS_THAN_EQUALS"
+	OperatorStartsWith               Operator = "STARTS_WITH"
+	OperatorStartsWithIgnoreCase     Operator = "STARTS_WITH_IGNORE_CASE"
+	OperatorContains                 Operator = "CONTAINS"
+	OperatorContainsIgnoreCase       Operator = "CONTAINS_IGNORE_CASE"
+	OperatorDoesNotContain           Operator = "DOES_NOT_CONTAIN"
+	OperatorDoesNotContainIgnoreCase Operator = "DOES_NOT_CONTAIN_IGNORE_CASE"
+	OperatorContainsAny              Operator = "CONTAINS_ANY"
+	OperatorContainsAll              Operator = "CONTAINS_ALL"
+	OperatorContainsNone             Operator = "CONTAINS_NONE"
+)
+
+// multiValued reports whether op takes any number of values rather than
+// exactly one.
+func (op Operator) multiValued() bool {
+	switch op {
+	case OperatorIn, OperatorNotIn, OperatorContainsAny, OperatorContainsAll, OperatorContainsNone:
+		return true
+	}
+	return false
+}
+
+func (op Operator) valid() bool {
+	switch op {
+	case OperatorEquals, OperatorNotEquals, OperatorIn, OperatorNotIn,
+		OperatorGreaterThan, OperatorGreaterThanEquals, OperatorLessThan, OperatorLessThanEquals,
+		OperatorStartsWith, OperatorStartsWithIgnoreCase, OperatorContains, OperatorContainsIgnoreCase,
+		OperatorDoesNotContain, OperatorDoesNotContainIgnoreCase,
+		OperatorContainsAny, OperatorContainsAll, OperatorContainsNone:
+		return true
+	}
+	return false
+}
+
+// FieldCatalog lists the fields a service selects and filters on, see
+// https://developers.google.com/adwords/api/docs/appendix/selectorfields
+type FieldCatalog struct {
+	Selectable []string // may be selected and ordered by
+	Filterable []string // may be used in predicates
+}
+
+func hasField(fields []string, field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// InvalidSelectorError is returned for selectors that would be rejected by
+// the API, e.g. selecting a field the service does not know.
+type InvalidSelectorError struct {
+	Service  string
+	Problems []string
+}
+
+func (e *InvalidSelectorError) Error() string {
+	return fmt.Sprintf("gads: invalid selector for %s: %s", e.Service, strings.Join(e.Problems, "; "))
+}
+
+// ValidateSelector checks selector against the catalog of service, or of
+// "Service.action", in SelectorFields.  The fields, predicates and ordering
+// must use known fields, and predicates must have the number of values
+// their operator takes.  Services without a catalog are rejected, as their
+// name is most likely misspelt; add a catalog to SelectorFields to validate
+// selectors of other services.
+func ValidateSelector(service string, selector Selector) error {
+	catalog, known := SelectorFields[service]
+	if !known {
+		if i := strings.Index(service, "."); i >= 0 {
+			catalog, known = SelectorFields[service[:i]]
+		}
+	}
+	if !known {
+		return &InvalidSelectorError{Service: service, Problems: []string{"no field catalog for " + service}}
+	}
+	return validateSelector(service, selector, catalog)
+}
+
+// validateSelector checks selector against catalog.
+func validateSelector(name string, selector Selector, catalog FieldCatalog) error {
+	problems := []string{}
+	if len(selector.Fields) == 0 {
+		problems = append(problems, "no field selected")
+	}
+	for _, f := range selector.Fields {
+		if !hasField(catalog.Selectable, f) {
+			problems = append(problems, fmt.Sprintf("field %q is not selectable", f))
+		}
+	}
+	for _, p := range selector.Predicates {
+		if !hasField(catalog.Filterable, p.Field) {
+			problems = append(problems, fmt.Sprintf("field %q is not filterable", p.Field))
+		}
+		op := Operator(p.Operator)
+		switch {
+		case !op.valid():
+			problems = append(problems, fmt.Sprintf("unknown operator %q on %q", p.Operator, p.Field))
+		case op.multiValued() && len(p.Values) == 0:
+			problems = append(problems, fmt.Sprintf("%s on %q needs at least one value", op, p.Field))
+		case !op.multiValued() && len(p.Values) != 1:
+			problems = append(problems, fmt.Sprintf("%s on %q takes a single value, got %d", op, p.Field, len(p.Values)))
+		}
+	}
+	for _, o := range selector.Ordering {
+		if !hasField(catalog.Selectable, o.Field) {
+			problems = append(problems, fmt.Sprintf("cannot order by %q", o.Field))
+		}
+		if o.SortOrder != "ASCENDING" && o.SortOrder != "DESCENDING" {
+			problems = append(problems, fmt.Sprintf("unknown sort order %q on %q", o.SortOrder, o.Field))
+		}
+	}
+	if selector.Paging != nil && (selector.Paging.Offset < 0 || selector.Paging.Limit < 0) {
+		problems = append(problems, "negative paging")
+	}
+	if len(problems) > 0 {
+		return &InvalidSelectorError{Service: name, Problems: problems}
+	}
+	return nil
+}
+
+// SelectorBuilder builds a Selector fluently, Build validates it against
+// the field catalog of a service.
+//
+// Example
+//
+//   selector, err := gads.NewSelector("Id", "Name", "Status").
+//     In("Status", "ENABLED", "PAUSED").
+//     StartsWith("Name", "Brand").
+//     OrderBy("Name").
+//     Paging(0, 100).
+//     Build("CampaignService")
+//
+type SelectorBuilder struct {
+	selector Selector
+}
+
+// NewSelector returns a builder of a selector of fields.
+func NewSelector(fields ...string) *SelectorBuilder {
+	return &SelectorBuilder{selector: Selector{Fields: fields}}
+}
+
+// Fields adds fields to the selected ones.
+func (b *SelectorBuilder) Fields(fields ...string) *SelectorBuilder {
+	b.selector.Fields = append(b.selector.Fields, fields...)
+	return b
+}
+
+// Where adds a predicate on field.
+func (b *SelectorBuilder) Where(field string, op Operator, values ...string) *SelectorBuilder {
+	b.selector.Predicates = append(b.selector.Predicates, Predicate{Field: field, Operator: string(op), Values: values})
+	return b
+}
+
+func (b *SelectorBuilder) Equals(field, value string) *SelectorBuilder {
+	return b.Where(field, OperatorEquals, value)
+}
+
+func (b *SelectorBuilder) NotEquals(field, value string) *SelectorBuilder {
+	return b.Where(field, OperatorNotEquals, value)
+}
+
+func (b *SelectorBuilder) In(field string, values ...string) *SelectorBuilder {
+	return b.Where(field, OperatorIn, values...)
+}
+
+func (b *SelectorBuilder) NotIn(field string, values ...string) *SelectorBuilder {
+	return b.Where(field, OperatorNotIn, values...)
+}
+
+func (b *SelectorBuilder) GreaterThan(field, value string) *SelectorBuilder {
+	return b.Where(field, OperatorGreaterThan, value)
+}
+
+func (b *SelectorBuilder) LessThan(field, value string) *SelectorBuilder {
+	return b.Where(field, OperatorLessThan, value)
+}
+
+// Between restricts field to the range from min to max included, the API
+// has no such operator so it adds two predicates.
+func (b *SelectorBuilder) Between(field, min, max string) *SelectorBuilder {
+	return b.Where(field, OperatorGreaterThanEquals, min).Where(field, OperatorLessThanEquals, max)
+}
+
+func (b *SelectorBuilder) StartsWith(field, value string) *SelectorBuilder {
+	return b.Where(field, OperatorStartsWith, value)
+}
+
+func (b *SelectorBuilder) Contains(field, value string) *SelectorBuilder {
+	return b.Where(field, OperatorContains, value)
+}
+
+func (b *SelectorBuilder) DoesNotContain(field, value string) *SelectorBuilder {
+	return b.Where(field, OperatorDoesNotContain, value)
+}
+
+// ContainsAny, ContainsAll and ContainsNone apply to fields holding lists,
+// like Labels.
+func (b *SelectorBuilder) ContainsAny(field string, values ...string) *SelectorBuilder {
+	return b.Where(field, OperatorContainsAny, values...)
+}
+
+func (b *SelectorBuilder) ContainsAll(field string, values ...string) *SelectorBuilder {
+	return b.Where(field, OperatorContainsAll, values...)
+}
+
+func (b *SelectorBuilder) ContainsNone(field string, values ...string) *SelectorBuilder {
+	return b.Where(field, OperatorContainsNone, values...)
+}
+
+// OrderBy sorts the entries by field in ascending order.
+func (b *SelectorBuilder) OrderBy(field string) *SelectorBuilder {
+	b.selector.Ordering = append(b.selector.Ordering, OrderBy{Field: field, SortOrder: "ASCENDING"})
+	return b
+}
+
+// OrderByDesc sorts the entries by field in descending order.
+func (b *SelectorBuilder) OrderByDesc(field string) *SelectorBuilder {
+	b.selector.Ordering = append(b.selector.Ordering, OrderBy{Field: field, SortOrder: "DESCENDING"})
+	return b
+}
+
+// During restricts the stats of the entries to the dates from min to max,
+// formatted as YYYYMMDD.
+func (b *SelectorBuilder) During(min, max string) *SelectorBuilder {
+	b.selector.DateRange = &DateRange{Min: min, Max: max}
+	return b
+}
+
+func (b *SelectorBuilder) Paging(offset, limit int64) *SelectorBuilder {
+	b.selector.Paging = &Paging{Offset: offset, Limit: limit}
+	return b
+}
+
+// Selector returns the selector built so far without validating it.
+func (b *SelectorBuilder) Selector() Selector {
+	s := b.selector
+	s.Fields = append([]string(nil), s.Fields...)
+	s.Predicates = append([]Predicate(nil), s.Predicates...)
+	s.Ordering = append([]OrderBy(nil), s.Ordering...)
+	return s
+}
+
+// Build returns the selector after validating it for service, see
+// ValidateSelector.
+func (b *SelectorBuilder) Build(service string) (Selector, error) {
+	s := b.Selector()
+	return s, ValidateSelector(service, s)
+}
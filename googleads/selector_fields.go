@@ -0,0 +1,305 @@
+package v201809
+
+// Field catalogs of the v201809 services, see
+// https://developers.google.com/adwords/api/docs/appendix/selectorfields
+//
+// Fields which are both selectable and filterable are listed once and
+// shared by the two lists of a catalog.
+
+var campaignFields = []string{
+	"AdServingOptimizationStatus", "AdvertisingChannelSubType", "AdvertisingChannelType", "Amount",
+	"AppId", "AppVendor", "BaseCampaignId", "BiddingStrategyGoalType", "BiddingStrategyId",
+	"BiddingStrategyName", "BiddingStrategyType", "BudgetId", "BudgetName", "BudgetReferenceCount",
+	"BudgetStatus", "CampaignGroupId", "CampaignTrialType", "DeliveryMethod", "Eligible", "EndDate",
+	"EnhancedCpcEnabled", "FinalUrlSuffix", "FrequencyCapMaxImpressions", "Id", "IsBudgetExplicitlyShared",
+	"Labels", "Level", "MaximizeConversionValueTargetRoas", "Name", "RejectionReasons", "ServingStatus",
+	"StartDate", "Status", "TargetContentNetwork", "TargetCpa", "TargetCpaMaxCpcBidCeiling",
+	"TargetCpaMaxCpcBidFloor", "TargetGoogleSearch", "TargetPartnerSearchNetwork", "TargetRoas",
+	"TargetRoasBidCeiling", "TargetRoasBidFloor", "TargetSearchNetwork", "TargetSpendBidCeiling",
+	"TargetSpendSpendTarget", "TimeUnit", "TrackingUrlTemplate", "VanityPharmaDisplayUrlMode",
+	"VanityPharmaText", "ViewableCpmEnabled",
+}
+
+var adGroupFields = []string{
+	"AdGroupType", "AdRotationMode", "BaseAdGroupId", "BaseCampaignId", "BiddingStrategyId",
+	"BiddingStrategyName", "BiddingStrategySource", "BiddingStrategyType", "CampaignId", "CampaignName",
+	"ContentBidCriterionTypeGroup", "CpcBid", "CpmBid", "CpvBid", "EnhancedCpcEnabled", "FinalUrlSuffix",
+	"Id", "Labels", "Name", "Status", "TargetCpa", "TargetCpaBid", "TargetCpaBidSource",
+	"TargetRoasOverride", "TrackingUrlTemplate",
+}
+
+var adGroupAdFields = []string{
+	"AdGroupId", "AdType", "Automated", "BaseAdGroupId", "BaseCampaignId", "CombinedApprovalStatus",
+	"CreativeFinalAppUrls", "CreativeFinalMobileUrls", "CreativeFinalUrlSuffix", "CreativeFinalUrls",
+	"CreativeTrackingUrlTemplate", "DevicePreference", "DisplayUrl", "Id", "Labels", "Status",
+	"SystemManagedEntitySource", "Url",
+	"Headline", "Description1", "Description2", // TextAd, DynamicSearchAd
+	// ExpandedTextAd
+	"HeadlinePart1", "HeadlinePart2", "ExpandedTextAdHeadlinePart3", "Description",
+	"ExpandedTextAdDescription2", "Path1", "Path2",
+	// ExpandedDynamicSearchAd
+	"ExpandedDynamicSearchCreativeDescription", "ExpandedDynamicSearchCreativeDescription2",
+	"ImageCreativeName", // ImageAd
+	// ResponsiveDisplayAd
+	"AccentColor", "AllowFlexibleColor", "BusinessName", "CallToActionText", "DynamicSettingsPricePrefix",
+	"DynamicSettingsPromoText", "FormatSetting", "LongHeadline", "MainColor", "ShortHeadline",
+	// MultiAssetResponsiveDisplayAd
+	"MultiAssetResponsiveDisplayAdAccentColor", "MultiAssetResponsiveDisplayAdAllowFlexibleColor",
+	"MultiAssetResponsiveDisplayAdBusinessName", "MultiAssetResponsiveDisplayAdCallToActionText",
+	"MultiAssetResponsiveDisplayAdDynamicSettingsPricePrefix",
+	"MultiAssetResponsiveDisplayAdDynamicSettingsPromoText", "MultiAssetResponsiveDisplayAdFormatSetting",
+	"MultiAssetResponsiveDisplayAdLongHeadline", "MultiAssetResponsiveDisplayAdMainColor",
+	"ResponsiveSearchAdPath1", "ResponsiveSearchAdPath2", // ResponsiveSearchAd
+	// CallOnlyAd
+	"CallOnlyAdBusinessName", "CallOnlyAdCallTracked", "CallOnlyAdConversionTypeId",
+	"CallOnlyAdCountryCode", "CallOnlyAdDescription1", "CallOnlyAdDescription2",
+	"CallOnlyAdDisableCallConversion", "CallOnlyAdDisplayUrl", "CallOnlyAdPhoneNumber",
+	"CallOnlyAdPhoneNumberVerificationUrl",
+	// TemplateAd
+	"TemplateAdDuration", "TemplateAdName", "TemplateAdUnionId", "TemplateId", "TemplateOriginAdId",
+	"UniqueName",
+	// GmailAd
+	"GmailTeaserBusinessName", "GmailTeaserDescription", "GmailTeaserHeadline",
+	"MarketingImageCallToActionText", "MarketingImageCallToActionTextColor", "MarketingImageDescription",
+	"MarketingImageHeadline",
+	"ShowcaseAdDescription", "ShowcaseAdHeadline", "ShowcaseAdName", // ShowcaseAd
+	"UniversalAppAdMandatoryAdText", // UniversalAppAd
+}
+
+var adGroupCriterionFields = []string{
+	"AdGroupId", "ApprovalStatus", "BaseAdGroupId", "BaseCampaignId", "BidModifier", "BiddingStrategyId",
+	"BiddingStrategyName", "BiddingStrategySource", "BiddingStrategyType", "CpcBid", "CpcBidSource",
+	"CpmBid", "CpmBidSource", "CpvBid", "CpvBidSource", "CriteriaType", "CriterionUse",
+	"DisapprovalReasons", "FinalAppUrls", "FinalMobileUrls", "FinalUrlSuffix", "FinalUrls",
+	"FirstPageCpc", "FirstPositionCpc", "Id", "Labels", "QualityScore", "Status", "SystemServingStatus",
+	"TopOfPageCpc", "TrackingUrlTemplate",
+	"AgeRangeType",                    // AgeRange
+	"AppPaymentModelType",             // AppPaymentModel
+	"CustomAffinityId",                // CustomAffinity
+	"CustomIntentId",                  // CustomIntent
+	"GenderType",                      // Gender
+	"IncomeRangeType",                 // IncomeRange
+	"KeywordMatchType", "KeywordText", // Keyword
+	"AppId", "DisplayName", "MobileAppCategoryId", // MobileApplication, MobileAppCategory
+	"ParentType",                                      // Parent
+	"PlacementUrl",                                    // Placement
+	"CaseValue", "ParentCriterionId", "PartitionType", // ProductPartition
+	"UserInterestId", "UserInterestName", // CriterionUserInterest
+	// CriterionUserList
+	"UserListEligibleForDisplay", "UserListEligibleForSearch", "UserListId", "UserListMembershipStatus",
+	"UserListName",
+	"Path", "VerticalId", "VerticalParentId", // Vertical
+	"ChannelId", "ChannelName", "VideoId", "VideoName", // YouTubeChannel, YouTubeVideo
+}
+
+var campaignCriterionFields = []string{
+	"BaseCampaignId", "BidModifier", "CampaignCriterionStatus", "CampaignId", "CriteriaType", "Id",
+	"IsNegative",
+	"AgeRangeType",                      // AgeRange
+	"AppPaymentModelType",               // AppPaymentModel
+	"CarrierCountryCode", "CarrierName", // Carrier
+	"ContentLabelType",                // ContentLabel
+	"CustomAffinityId",                // CustomAffinity
+	"CustomIntentId",                  // CustomIntent
+	"GenderType",                      // Gender
+	"IncomeRangeType",                 // IncomeRange
+	"KeywordMatchType", "KeywordText", // Keyword
+	"LanguageCode", "LanguageName", // Language
+	"LocationName", "TargetingStatus", // Location
+	"FeedId",                                      // LocationGroups
+	"AppId", "DisplayName", "MobileAppCategoryId", // MobileApplication, MobileAppCategory
+	"DeviceName", "DeviceType", "ManufacturerName", "OperatingSystemName", // MobileDevice
+	"OperatorType", "OsMajorVersion", "OsMinorVersion", // OperatingSystemVersion
+	"PlacementUrl",                       // Placement
+	"PlatformName",                       // Platform
+	"UserInterestId", "UserInterestName", // CriterionUserInterest
+	"UserListId", "UserListMembershipStatus", "UserListName", // CriterionUserList
+	"Path", "VerticalId", "VerticalParentId", // Vertical
+	"ChannelId", "ChannelName", "VideoId", "VideoName", // YouTubeChannel, YouTubeVideo
+}
+
+var userListFields = []string{
+	"AccessReason", "AccountUserListStatus", "AppId", "ClosingReason", "DataSourceType", "Id",
+	"IntegrationCode", "IsEligibleForDisplay", "IsEligibleForSearch", "IsReadOnly", "ListType",
+	"MembershipLifeSpan", "Name", "PrepopulationStatus", "Size", "SizeForSearch", "SizeRange",
+	"SizeRangeForSearch", "Status", "UploadKeyType",
+	"SeedListSize", "SeedUserListId", // SimilarUserList
+}
+
+var mediaFields = []string{
+	"AdvertisingId", "CreationTime", "DurationMillis", "FileSize", "Height",
+	"IndustryStandardCommercialIdentifier", "MediaId", "MimeType", "Name", "ReadyToPlayOnTheWeb",
+	"ReferenceId", "SourceUrl", "StreamingUrl", "Type", "Width", "YouTubeVideoIdString",
+}
+
+var sharedCriterionFields = []string{
+	"CriteriaType", "Id", "Negative", "SharedSetId",
+	"KeywordMatchType", "KeywordText", // Keyword
+	"AppId", "DisplayName", "MobileAppCategoryId", // MobileApplication, MobileAppCategory
+	"PlacementUrl",                                     // Placement
+	"ChannelId", "ChannelName", "VideoId", "VideoName", // YouTubeChannel, YouTubeVideo
+}
+
+var (
+	budgetFields            = []string{"Amount", "BudgetId", "BudgetName", "BudgetReferenceCount", "BudgetStatus", "DeliveryMethod", "IsBudgetExplicitlyShared"}
+	labelFields             = []string{"LabelId", "LabelName", "LabelStatus"}
+	sharedSetFields         = []string{"MemberCount", "Name", "ReferenceCount", "SharedSetId", "Status", "Type"}
+	campaignSharedSetFields = []string{"CampaignId", "CampaignName", "SharedSetId", "SharedSetName", "SharedSetType", "Status"}
+	managedCustomerFields   = []string{"AccountLabels", "CanManageClients", "CurrencyCode", "CustomerId", "DateTimeZone", "ExcludeHiddenAccounts", "Name", "TestAccount"}
+	adGroupFeedFields       = []string{"AdGroupId", "BaseAdGroupId", "BaseCampaignId", "FeedId", "PlaceholderTypes", "Status"}
+	adParamFields           = []string{"AdGroupId", "CriterionId", "InsertionText", "ParamIndex"}
+	feedFields              = []string{"FeedStatus", "FeedType", "Id", "Name", "Origin"}
+	productCategoryFields   = []string{"Country", "DimensionValue", "DisplayValue", "ParentDimensionValue", "Status"}
+)
+
+var bidLandscapeFields = []string{
+	"AdGroupId", "Bid", "BiddableConversions", "BiddableConversionsValue", "CampaignId", "LandscapeCurrent",
+	"LandscapeType", "LocalClicks", "LocalCost", "LocalImpressions", "PromotedImpressions",
+}
+
+var campaignCriterionBidLandscapeFields = []string{
+	"BidModifier", "BiddableConversions", "BiddableConversionsValue", "CampaignId", "CriterionId",
+	"LandscapeCurrent", "LandscapeType", "LocalClicks", "LocalCost", "LocalImpressions",
+	"PromotedImpressions", "RequiredBudget", "TotalLocalClicks", "TotalLocalCost", "TotalLocalImpressions",
+	"TotalLocalPromotedImpressions",
+}
+
+// withFields returns a copy of fields with more appended.
+func withFields(fields []string, more ...string) []string {
+	return append(append([]string(nil), fields...), more...)
+}
+
+// SelectorFields holds the field catalogs selectors are validated against,
+// by service or by "Service.action" for services whose actions differ, like
+// "DataService.getAdGroupBidLandscape".  Every service of this package
+// taking a Selector or an AWQL query has a catalog, applications may add
+// catalogs for other services.
+var SelectorFields = map[string]FieldCatalog{
+	"CampaignService": {
+		Selectable: withFields(campaignFields, "SelectiveOptimization", "Settings", "UrlCustomParameters"),
+		Filterable: campaignFields,
+	},
+	"AdGroupService": {
+		Selectable: withFields(adGroupFields, "Settings", "UrlCustomParameters"),
+		Filterable: adGroupFields,
+	},
+	"AdGroupAdService": {
+		Selectable: withFields(adGroupAdFields,
+			"AdStrengthInfo", "CreativeUrlCustomParameters", "PolicySummary", "UrlData",
+			// ImageAd
+			"Dimensions", "FileSize", "Height", "MediaId", "MimeType", "Name", "ReadyToPlayOnTheWeb",
+			"ReferenceId", "SourceUrl", "Type", "Urls", "Width",
+			"LogoImage", "MarketingImage", "SquareMarketingImage", // ResponsiveDisplayAd
+			// MultiAssetResponsiveDisplayAd
+			"MultiAssetResponsiveDisplayAdDescriptions", "MultiAssetResponsiveDisplayAdHeadlines",
+			"MultiAssetResponsiveDisplayAdLandscapeLogoImages", "MultiAssetResponsiveDisplayAdLogoImages",
+			"MultiAssetResponsiveDisplayAdMarketingImages", "MultiAssetResponsiveDisplayAdSquareMarketingImages",
+			"MultiAssetResponsiveDisplayAdYouTubeVideos",
+			"ResponsiveSearchAdDescriptions", "ResponsiveSearchAdHeadlines", // ResponsiveSearchAd
+			"TemplateElementFieldName", "TemplateElementFieldText", "TemplateElementFieldType", // TemplateAd
+			"GmailHeaderImage", "GmailMarketingImage", "GmailTeaserLogoImage", "ProductImages", "ProductVideoList", // GmailAd
+			"ShowcaseAdCollapsedImage", "ShowcaseAdExpandedImage", // ShowcaseAd
+			// UniversalAppAd
+			"UniversalAppAdDescriptions", "UniversalAppAdHeadlines", "UniversalAppAdHtml5MediaBundles",
+			"UniversalAppAdImages", "UniversalAppAdYouTubeVideos",
+		),
+		Filterable: adGroupAdFields,
+	},
+	"AdGroupCriterionService": {
+		Selectable: withFields(adGroupCriterionFields, "UrlCustomParameters",
+			"Parameter", "CriteriaCoverage", "CriteriaSamples", // Webpage
+		),
+		Filterable: adGroupCriterionFields,
+	},
+	"AdGroupFeedService": {
+		Selectable: withFields(adGroupFeedFields, "MatchingFunction"),
+		Filterable: adGroupFeedFields,
+	},
+	"AdParamService": {
+		Selectable: adParamFields,
+		Filterable: adParamFields,
+	},
+	"AdwordsUserListService": {
+		Selectable: withFields(userListFields,
+			"ConversionTypes", "DataUploadResult", "Description", "Rules",
+			"SeedUserListDescription", "SeedUserListName", "SeedUserListStatus", // SimilarUserList
+		),
+		Filterable: userListFields,
+	},
+	"BatchJobService": {
+		Selectable: []string{"DiagnosticsUrl", "DownloadUrl", "Id", "ProcessingErrors", "ProgressStats", "Status", "UploadUrl"},
+		Filterable: []string{"Id", "Status"},
+	},
+	"BudgetService": {
+		Selectable: budgetFields,
+		Filterable: budgetFields,
+	},
+	"CampaignCriterionService": {
+		Selectable: withFields(campaignCriterionFields,
+			"Address", "GeoPoint", "RadiusDistanceUnits", "RadiusInUnits", // Proximity
+			"DayOfWeek", "EndHour", "EndMinute", "StartHour", "StartMinute", // AdSchedule
+			"IpAddress",                                        // IpBlock
+			"MatchingFunction",                                 // LocationGroups
+			"ParentLocations",                                  // Location
+			"Dimensions",                                       // ProductScope
+			"Parameter", "CriteriaCoverage", "CriteriaSamples", // Webpage
+		),
+		Filterable: campaignCriterionFields,
+	},
+	"CampaignSharedSetService": {
+		Selectable: campaignSharedSetFields,
+		Filterable: campaignSharedSetFields,
+	},
+	"ConstantDataService.getProductBiddingCategoryData": {
+		Selectable: productCategoryFields,
+		Filterable: productCategoryFields,
+	},
+	"LabelService": {
+		Selectable: withFields(labelFields, "LabelAttribute"),
+		Filterable: labelFields,
+	},
+	"LocationCriterionService": {
+		Selectable: []string{"CanonicalName", "DisplayType", "Id", "LocationName", "ParentLocations", "Reach", "TargetingStatus"},
+		Filterable: []string{"CanonicalName", "DisplayType", "Id", "Locale", "LocationName", "TargetingStatus"},
+	},
+	"ManagedCustomerService": {
+		Selectable: managedCustomerFields,
+		Filterable: managedCustomerFields,
+	},
+	"MediaService": {
+		Selectable: withFields(mediaFields, "Dimensions", "Urls"),
+		Filterable: mediaFields,
+	},
+	"SharedCriterionService": {
+		Selectable: sharedCriterionFields,
+		Filterable: sharedCriterionFields,
+	},
+	"SharedSetService": {
+		Selectable: sharedSetFields,
+		Filterable: sharedSetFields,
+	},
+	"AdGroupExtensionSettingService": {
+		Selectable: []string{"AdGroupId", "ExtensionType", "Extensions", "Platform"},
+		Filterable: []string{"AdGroupId", "ExtensionType", "Platform"},
+	},
+	"CampaignExtensionSettingService": {
+		Selectable: []string{"CampaignId", "ExtensionType", "Extensions", "Platform"},
+		Filterable: []string{"CampaignId", "ExtensionType", "Platform"},
+	},
+	"FeedService": {
+		Selectable: withFields(feedFields, "Attributes", "SystemFeedGenerationData"),
+		Filterable: feedFields,
+	},
+	"DataService.getAdGroupBidLandscape": {
+		Selectable: withFields(bidLandscapeFields, "EndDate", "StartDate"),
+		Filterable: bidLandscapeFields,
+	},
+	"DataService.getCriterionBidLandscape": {
+		Selectable: withFields(bidLandscapeFields, "CriterionId", "EndDate", "StartDate"),
+		Filterable: withFields(bidLandscapeFields, "CriterionId"),
+	},
+	"DataService.getCampaignCriterionBidLandscape": {
+		Selectable: withFields(campaignCriterionBidLandscapeFields, "EndDate", "StartDate"),
+		Filterable: campaignCriterionBidLandscapeFields,
+	},
+}
@@ -0,0 +1,116 @@
+package v201809
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSelectorBuilder(t *testing.T) {
+	selector, err := NewSelector("Id", "Name").
+		Fields("Status").
+		In("Status", "ENABLED", "PAUSED").
+		Between("StartDate", "20180101", "20181231").
+		ContainsAny("Labels", "1", "2").
+		OrderByDesc("Name").
+		Paging(0, 100).
+		Build("CampaignService")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Selector{
+		Fields: []string{"Id", "Name", "Status"},
+		Predicates: []Predicate{
+			{"Status", "IN", []string{"ENABLED", "PAUSED"}},
+			{"StartDate", "GREATER_THAN_EQUALS", []string{"20180101"}},
+			{"StartDate", "LESS_THAN_EQUALS", []string{"20181231"}},
+			{"Labels", "CONTAINS_ANY", []string{"1", "2"}},
+		},
+		Ordering: []OrderBy{{"Name", "DESCENDING"}},
+		Paging:   &Paging{Offset: 0, Limit: 100},
+	}
+	if !reflect.DeepEqual(selector, expected) {
+		t.Errorf("got %#v", selector)
+	}
+}
+
+func TestSelectorValidation(t *testing.T) {
+	_, err := NewSelector("Id", "Nmae").
+		Equals("Settings", "x").
+		Where("Status", OperatorEquals, "ENABLED", "PAUSED").
+		In("Id").
+		OrderBy("Budget").
+		Build("CampaignService")
+	var ise *InvalidSelectorError
+	if !errors.As(err, &ise) {
+		t.Fatalf("expected an InvalidSelectorError, got %v", err)
+	}
+	expected := []string{
+		`field "Nmae" is not selectable`,
+		`field "Settings" is not filterable`,
+		`EQUALS on "Status" takes a single value, got 2`,
+		`IN on "Id" needs at least one value`,
+		`cannot order by "Budget"`,
+	}
+	if !reflect.DeepEqual(ise.Problems, expected) {
+		t.Errorf("got %q", ise.Problems)
+	}
+
+	if err := ValidateSelector("DataService.getCriterionBidLandscape", NewSelector("CriterionId").Selector()); err != nil {
+		t.Error(err)
+	}
+	if err := ValidateSelector("DataService.getAdGroupBidLandscape", NewSelector("CriterionId").Selector()); err == nil {
+		t.Error("expected CriterionId not to be selectable for ad group bid landscapes")
+	}
+	if err := ValidateSelector("BudgetService", NewSelector("BudgetId").Where("BudgetId", "LIKE", "1").Selector()); err == nil {
+		t.Error("expected an unknown operator to be rejected")
+	}
+	if err := ValidateSelector("CampaignSevice", NewSelector("Id").Selector()); !errors.As(err, &ise) {
+		t.Errorf("expected a service without catalog to be rejected, got %v", err)
+	}
+}
+
+func TestSelectorValidSelectors(t *testing.T) {
+	for _, tt := range []struct {
+		service  string
+		selector *SelectorBuilder
+	}{
+		{"CampaignService", NewSelector("Id", "BudgetId", "BiddingStrategyType", "Amount")},
+		{"CampaignService", NewSelector("Id", "Name", "Status", "AdvertisingChannelType", "AdvertisingChannelSubType",
+			"StartDate", "EndDate", "BudgetId", "Labels", "Settings").
+			In("Status", "ENABLED", "PAUSED").Equals("BudgetId", "1").OrderBy("Name")},
+		{"AdGroupService", NewSelector("Id", "CampaignId", "Name", "Status", "CpcBid", "Settings").Equals("CampaignId", "1")},
+		{"AdGroupAdService", NewSelector("AdGroupId", "Id", "Status", "HeadlinePart1", "HeadlinePart2", "Description",
+			"CreativeFinalUrls", "PolicySummary").Equals("CombinedApprovalStatus", "APPROVED")},
+		{"AdGroupCriterionService", NewSelector("AdGroupId", "BidModifier", "CriterionUse", "ParentCriterionId",
+			"CriteriaType", "CaseValue", "Id", "BiddingStrategyType", "CpcBid", "BiddingStrategyId", "PartitionType").
+			Equals("AdGroupId", "1")},
+		{"AdGroupCriterionService", NewSelector("Id", "KeywordText", "KeywordMatchType", "QualityScore").
+			In("KeywordMatchType", "EXACT", "PHRASE")},
+		{"CampaignCriterionService", NewSelector("CampaignId", "Id", "CriteriaType", "IsNegative", "LocationName",
+			"BidModifier").Equals("CampaignId", "1")},
+		{"BudgetService", NewSelector("Amount", "BudgetId", "BudgetName", "DeliveryMethod").Equals("BudgetId", "1")},
+		{"LabelService", NewSelector("LabelId", "LabelName", "LabelStatus", "LabelAttribute")},
+		{"ManagedCustomerService", NewSelector("CustomerId", "Name", "CanManageClients").OrderBy("CustomerId")},
+		{"MediaService", NewSelector("MediaId", "Type", "Width", "Height", "MimeType", "Urls").In("Type", "IMAGE", "VIDEO")},
+		{"SharedSetService", NewSelector("SharedSetId", "Name", "Type", "Status")},
+		{"SharedCriterionService", NewSelector("Id", "SharedSetId", "Negative", "KeywordText").Equals("SharedSetId", "1")},
+		{"CampaignSharedSetService", NewSelector("SharedSetId", "CampaignId", "SharedSetName")},
+		{"AdwordsUserListService", NewSelector("Id", "Name", "Status", "Size", "ListType")},
+		{"AdGroupFeedService", NewSelector("AdGroupId", "FeedId", "Status", "MatchingFunction")},
+		{"AdParamService", NewSelector("AdGroupId", "CriterionId", "InsertionText", "ParamIndex")},
+		{"BatchJobService", NewSelector("Id", "Status", "ProgressStats", "DownloadUrl", "ProcessingErrors")},
+		{"LocationCriterionService", NewSelector("Id", "LocationName", "CanonicalName", "DisplayType",
+			"ParentLocations", "Reach", "TargetingStatus").
+			In("LocationName", "Cameroon", "India").Equals("Locale", "en")},
+		{"ConstantDataService.getProductBiddingCategoryData", NewSelector("DimensionValue", "ParentDimensionValue",
+			"DisplayValue").Equals("Country", "US")},
+		{"DataService.getCampaignCriterionBidLandscape", NewSelector("CampaignId", "CriterionId", "BidModifier",
+			"LocalClicks", "StartDate", "EndDate")},
+		{"FeedService", NewSelector("Id", "Name", "Attributes", "FeedStatus")},
+	} {
+		if err := ValidateSelector(tt.service, tt.selector.Selector()); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package v201809
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 )
@@ -24,9 +25,16 @@ type SharedCriterionOperation struct {
 	Operand  SharedCriterion `xml:"operand,omitempty"`
 }
 
-func (s SharedCriterionService) Get(selector Selector) (sharedCriteria []SharedCriterion, totalCount int64, err error) {
+func (s SharedCriterionService) Get(selector Selector, opts ...CallOption) (sharedCriteria []SharedCriterion, totalCount int64, err error) {
+	return s.GetWithContext(context.Background(), selector, opts...)
+}
+
+// GetWithContext is the same as Get with the addition of a context.
+func (s SharedCriterionService) GetWithContext(ctx context.Context, selector Selector, opts ...CallOption) (sharedCriteria []SharedCriterion, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 	selector.XMLName = xml.Name{baseUrl, "selector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		sharedCriterionServiceUrl,
 		"get",
 		struct {
@@ -54,7 +62,13 @@ func (s SharedCriterionService) Get(selector Selector) (sharedCriteria []SharedC
 	return getResp.SharedCriteria, getResp.Size, err
 }
 
-func (s SharedCriterionService) Mutate(operations []SharedCriterionOperation) error {
+func (s SharedCriterionService) Mutate(operations []SharedCriterionOperation, opts ...CallOption) error {
+	return s.MutateWithContext(context.Background(), operations, opts...)
+}
+
+// MutateWithContext is the same as Mutate with the addition of a context.
+func (s SharedCriterionService) MutateWithContext(ctx context.Context, operations []SharedCriterionOperation, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
 	mutateRequest := struct {
 		XMLName xml.Name
 		Ops     []SharedCriterionOperation `xml:"operations"`
@@ -64,8 +78,17 @@ func (s SharedCriterionService) Mutate(operations []SharedCriterionOperation) er
 			Local: "mutate",
 		},
 		Ops: operations}
-	_, err := s.Auth.request(sharedCriterionServiceUrl, "mutate", mutateRequest)
-	return err
+	respBody, err := s.Auth.request(ctx, sharedCriterionServiceUrl, "mutate", mutateRequest)
+	if err != nil {
+		return err
+	}
+	mutateResp := struct {
+		PartialFailureErrors partialFailureErrors `xml:"rval>partialFailureErrors"`
+	}{}
+	if err := xml.Unmarshal(respBody, &mutateResp); err != nil {
+		return err
+	}
+	return partialFailure(operations, mutateResp.PartialFailureErrors)
 }
 
 func (s *SharedCriterion) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
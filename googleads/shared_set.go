@@ -1,6 +1,9 @@
 package v201809
 
-import "encoding/xml"
+import (
+	"context"
+	"encoding/xml"
+)
 
 type SharedSetService struct {
 	Auth
@@ -24,9 +27,16 @@ type SharedSetOperation struct {
 	Operand  SharedSet `xml:"operand,omitempty"`
 }
 
-func (s SharedSetService) Get(selector Selector) (sharedSets []SharedSet, totalCount int64, err error) {
+func (s SharedSetService) Get(selector Selector, opts ...CallOption) (sharedSets []SharedSet, totalCount int64, err error) {
+	return s.GetWithContext(context.Background(), selector, opts...)
+}
+
+// GetWithContext is the same as Get with the addition of a context.
+func (s SharedSetService) GetWithContext(ctx context.Context, selector Selector, opts ...CallOption) (sharedSets []SharedSet, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 	selector.XMLName = xml.Name{baseUrl, "selector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		sharedSetServiceUrl,
 		"get",
 		struct {
@@ -54,7 +64,42 @@ func (s SharedSetService) Get(selector Selector) (sharedSets []SharedSet, totalC
 	return getResp.SharedSets, getResp.Size, err
 }
 
-func (s SharedSetService) Mutate(operations []SharedSetOperation) ([]SharedSet, error) {
+// SharedSetIterator walks the shared sets of a selector, see
+// SharedSetService.Iterate.
+type SharedSetIterator struct {
+	*pageIterator
+}
+
+// SharedSet returns the current shared set.
+func (it *SharedSetIterator) SharedSet() SharedSet {
+	return it.page.([]SharedSet)[it.index]
+}
+
+// Iterate returns an iterator over all shared sets matching selector, which
+// fetches them page by page, see CampaignService.Iterate.
+func (s SharedSetService) Iterate(selector Selector, opts ...CallOption) *SharedSetIterator {
+	return s.IterateWithContext(context.Background(), selector, opts...)
+}
+
+// IterateWithContext is the same as Iterate with the addition of a context,
+// the iteration stops with the error of the context once it is done.
+func (s SharedSetService) IterateWithContext(ctx context.Context, selector Selector, opts ...CallOption) *SharedSetIterator {
+	it := &SharedSetIterator{pageIterator: newPageIterator(ctx, selector, "SharedSetId")}
+	it.fetch = func(ctx context.Context, selector Selector) (interface{}, int, int64, error) {
+		sharedSets, totalCount, err := s.GetWithContext(ctx, selector, opts...)
+		return sharedSets, len(sharedSets), totalCount, err
+	}
+	it.id = func(i int) int64 { return it.page.([]SharedSet)[i].Id }
+	return it
+}
+
+func (s SharedSetService) Mutate(operations []SharedSetOperation, opts ...CallOption) ([]SharedSet, error) {
+	return s.MutateWithContext(context.Background(), operations, opts...)
+}
+
+// MutateWithContext is the same as Mutate with the addition of a context.
+func (s SharedSetService) MutateWithContext(ctx context.Context, operations []SharedSetOperation, opts ...CallOption) ([]SharedSet, error) {
+	ctx = withCallOptions(ctx, opts)
 	mutateRequest := struct {
 		XMLName xml.Name
 		Ops     []SharedSetOperation `xml:"operations"`
@@ -65,19 +110,20 @@ func (s SharedSetService) Mutate(operations []SharedSetOperation) ([]SharedSet,
 		},
 		Ops: operations}
 
-	respBody, err := s.Auth.request(sharedSetServiceUrl, "mutate", mutateRequest)
+	respBody, err := s.Auth.request(ctx, sharedSetServiceUrl, "mutate", mutateRequest)
 
 	if err != nil {
 		return nil, err
 	}
 
 	getResp := struct {
-		ListReturnValueType string      `xml:"rval>ListReturnValue.Type"`
-		SharedSets          []SharedSet `xml:"rval>value"`
+		ListReturnValueType  string               `xml:"rval>ListReturnValue.Type"`
+		SharedSets           []SharedSet          `xml:"rval>value"`
+		PartialFailureErrors partialFailureErrors `xml:"rval>partialFailureErrors"`
 	}{}
 	err = xml.Unmarshal([]byte(respBody), &getResp)
 	if err != nil {
 		return nil, err
 	}
-	return getResp.SharedSets, nil
+	return getResp.SharedSets, partialFailure(operations, getResp.PartialFailureErrors)
 }
@@ -1,28 +1,117 @@
 package v201809
 
-import "time"
+import (
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
 
 type CallStatItem struct {
 	Requests  int
 	Cached    int
 	MemCached int
+	Errors    int
+	Retries   int
 	TotalTime time.Duration
 	ReqTime   time.Duration
 	CacheTime time.Duration
+
+	// CacheHitRatio is Cached / Requests, it is set in the snapshots
+	// returned by GetStat.
+	CacheHitRatio float64
+
+	// Latency of the requests, including cached ones.
+	Latency LatencyHistogram
+}
+
+// LatencyBuckets are the upper bounds of the latency histograms.
+var LatencyBuckets = []time.Duration{
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	time.Minute,
+}
+
+// LatencyHistogram counts requests by latency.  Counts[i] is the number of
+// requests which took at most LatencyBuckets[i] and more than the previous
+// bucket, slower requests are only part of Count.
+type LatencyHistogram struct {
+	Counts []int
+	Count  int
+	Sum    time.Duration
+}
+
+func (h *LatencyHistogram) observe(t time.Duration) {
+	if h.Counts == nil {
+		h.Counts = make([]int, len(LatencyBuckets))
+	}
+	for i, le := range LatencyBuckets {
+		if t <= le {
+			h.Counts[i]++
+			break
+		}
+	}
+	h.Count++
+	h.Sum += t
 }
 
 type CallStat struct {
 	CallStatItem
 	ServiceStat map[string]*CallStatItem
+	ActionStat  map[string]*CallStatItem // by "Service.action", e.g. "CampaignService.get"
+	ErrorStat   map[string]int           // by reason, e.g. "RateExceededError.RATE_EXCEEDED"
 }
 
 var (
-	stat = CallStat{
+	statMu sync.Mutex // guards the content of stat
+	stat   = newCallStat()
+)
+
+func newCallStat() *CallStat {
+	return &CallStat{
 		ServiceStat: map[string]*CallStatItem{},
+		ActionStat:  map[string]*CallStatItem{},
+		ErrorStat:   map[string]int{},
 	}
-)
+}
+
+// StatVar is the name GetStat is published under with expvar, it carries
+// the API version so that the packages of several versions can be linked
+// into the same binary.
+const StatVar = "gads_" + version
 
-func (s *CallStat) count(service string, cached, mem bool, t time.Duration) {
+func init() {
+	expvar.Publish(StatVar, expvar.Func(func() interface{} {
+		return GetStat()
+	}))
+}
+
+// items returns the stat items a request of service and action counts in.
+func (s *CallStat) items(service, action string) []*CallStatItem {
+	if _, ok := s.ServiceStat[service]; !ok {
+		s.ServiceStat[service] = &CallStatItem{}
+	}
+	key := service + "." + action
+	if _, ok := s.ActionStat[key]; !ok {
+		s.ActionStat[key] = &CallStatItem{}
+	}
+	return []*CallStatItem{&s.CallStatItem, s.ServiceStat[service], s.ActionStat[key]}
+}
+
+func (s *CallStat) count(service, action string, cached, mem bool, t time.Duration, err error) {
 	// calc values
 	reqDuration := time.Second * 0
 	cacheDuration := time.Second * 0
@@ -37,26 +126,168 @@ func (s *CallStat) count(service string, cached, mem bool, t time.Duration) {
 	} else {
 		reqDuration += t
 	}
-	// update total stat
-	s.Requests++
-	s.Cached += cachedcnt
-	s.MemCached += memcachedcnt
-	s.TotalTime += reqDuration + cacheDuration
-	s.ReqTime += reqDuration
-	s.CacheTime += cacheDuration
-	// init service stat
-	if _, ok := s.ServiceStat[service]; !ok {
-		s.ServiceStat[service] = &CallStatItem{}
+	errcnt := 0
+	if err != nil {
+		errcnt++
+	}
+
+	statMu.Lock()
+	defer statMu.Unlock()
+	for _, item := range s.items(service, action) {
+		item.Requests++
+		item.Cached += cachedcnt
+		item.MemCached += memcachedcnt
+		item.Errors += errcnt
+		item.TotalTime += reqDuration + cacheDuration
+		item.ReqTime += reqDuration
+		item.CacheTime += cacheDuration
+		item.Latency.observe(t)
+	}
+	for _, reason := range errorReasons(err) {
+		s.ErrorStat[reason]++
+	}
+}
+
+// retry counts a request of service and action being sent again.
+func (s *CallStat) retry(service, action string) {
+	statMu.Lock()
+	defer statMu.Unlock()
+	for _, item := range s.items(service, action) {
+		item.Retries++
+	}
+}
+
+// errorReasons returns the reasons err is counted under in ErrorStat.
+func errorReasons(err error) (reasons []string) {
+	if err == nil {
+		return nil
+	}
+	for _, fe := range faultErrors(err) {
+		reasons = append(reasons, fe.ErrorType()+"."+fe.ErrorReason())
+	}
+	if len(reasons) > 0 {
+		return reasons
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return []string{"HTTP." + strconv.Itoa(httpErr.StatusCode)}
+	}
+	var e Error
+	if errors.As(err, &e) && e.Code() != "" {
+		return []string{e.Code()}
+	}
+	return []string{"OTHER"}
+}
+
+func (s CallStatItem) snapshot() *CallStatItem {
+	if s.Requests > 0 {
+		s.CacheHitRatio = float64(s.Cached) / float64(s.Requests)
 	}
-	// update service stat
-	s.ServiceStat[service].Requests++
-	s.ServiceStat[service].Cached += cachedcnt
-	s.ServiceStat[service].MemCached += memcachedcnt
-	s.ServiceStat[service].TotalTime += reqDuration + cacheDuration
-	s.ServiceStat[service].ReqTime += reqDuration
-	s.ServiceStat[service].CacheTime += cacheDuration
+	s.Latency.Counts = append([]int(nil), s.Latency.Counts...)
+	return &s
 }
 
+// GetStat returns a snapshot of the statistics of all requests made so far.
+// Unlike in earlier releases, which returned the live statistics, later
+// requests do not update the returned CallStat; call GetStat again instead.
 func GetStat() *CallStat {
-	return &stat
+	statMu.Lock()
+	defer statMu.Unlock()
+	snapshot := newCallStat()
+	snapshot.CallStatItem = *stat.CallStatItem.snapshot()
+	for k, v := range stat.ServiceStat {
+		snapshot.ServiceStat[k] = v.snapshot()
+	}
+	for k, v := range stat.ActionStat {
+		snapshot.ActionStat[k] = v.snapshot()
+	}
+	for k, v := range stat.ErrorStat {
+		snapshot.ErrorStat[k] = v
+	}
+	return snapshot
+}
+
+// ResetStat clears the statistics, e.g. between the runs of a job.
+func ResetStat() {
+	statMu.Lock()
+	defer statMu.Unlock()
+	*stat = *newCallStat()
+}
+
+// StatHandler returns a handler serving the statistics in the Prometheus
+// text format, to be mounted in the HTTP server of the application.
+//
+// Example
+//
+//   http.Handle("/metrics", gads.StatHandler())
+//
+func StatHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		writePrometheus(w, GetStat())
+	})
+}
+
+// writePrometheus writes s in the Prometheus text format.
+func writePrometheus(w io.Writer, s *CallStat) {
+	actions := []string{}
+	for k := range s.ActionStat {
+		actions = append(actions, k)
+	}
+	sort.Strings(actions)
+	labels := func(action string) string {
+		parts := strings.SplitN(action, ".", 2)
+		return fmt.Sprintf(`service="%s",action="%s"`, promEscape(parts[0]), promEscape(parts[1]))
+	}
+
+	counters := []struct {
+		name, help string
+		value      func(*CallStatItem) int
+	}{
+		{"gads_requests_total", "Requests made to the AdWords API, including cached ones.", func(i *CallStatItem) int { return i.Requests }},
+		{"gads_cached_requests_total", "Requests answered from the cache.", func(i *CallStatItem) int { return i.Cached }},
+		{"gads_errors_total", "Requests which failed.", func(i *CallStatItem) int { return i.Errors }},
+		{"gads_retries_total", "Requests sent again after a failure.", func(i *CallStatItem) int { return i.Retries }},
+	}
+	for _, c := range counters {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+		for _, action := range actions {
+			fmt.Fprintf(w, "%s{%s} %d\n", c.name, labels(action), c.value(s.ActionStat[action]))
+		}
+	}
+
+	fmt.Fprintf(w, "# HELP gads_cache_hit_ratio Share of the requests answered from the cache.\n# TYPE gads_cache_hit_ratio gauge\n")
+	for _, action := range actions {
+		fmt.Fprintf(w, "gads_cache_hit_ratio{%s} %g\n", labels(action), s.ActionStat[action].CacheHitRatio)
+	}
+
+	reasons := []string{}
+	for k := range s.ErrorStat {
+		reasons = append(reasons, k)
+	}
+	sort.Strings(reasons)
+	fmt.Fprintf(w, "# HELP gads_error_reasons_total Errors by reason, e.g. RateExceededError.RATE_EXCEEDED.\n# TYPE gads_error_reasons_total counter\n")
+	for _, reason := range reasons {
+		fmt.Fprintf(w, "gads_error_reasons_total{reason=\"%s\"} %d\n", promEscape(reason), s.ErrorStat[reason])
+	}
+
+	fmt.Fprintf(w, "# HELP gads_request_duration_seconds Latency of the requests.\n# TYPE gads_request_duration_seconds histogram\n")
+	for _, action := range actions {
+		h := s.ActionStat[action].Latency
+		cumulative := 0
+		for i, le := range LatencyBuckets {
+			if i < len(h.Counts) {
+				cumulative += h.Counts[i]
+			}
+			fmt.Fprintf(w, "gads_request_duration_seconds_bucket{%s,le=\"%g\"} %d\n", labels(action), le.Seconds(), cumulative)
+		}
+		fmt.Fprintf(w, "gads_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels(action), h.Count)
+		fmt.Fprintf(w, "gads_request_duration_seconds_sum{%s} %g\n", labels(action), h.Sum.Seconds())
+		fmt.Fprintf(w, "gads_request_duration_seconds_count{%s} %d\n", labels(action), h.Count)
+	}
+}
+
+// promEscape escapes a Prometheus label value.
+func promEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
 }
@@ -0,0 +1,70 @@
+package v201809
+
+import (
+	"expvar"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStat(t *testing.T) {
+	ResetStat()
+	defer ResetStat()
+
+	client := &countingClient{status: 200, body: soapGetResponse("")}
+	auth := &Auth{
+		Client:      client,
+		RetryPolicy: ExponentialBackoff{MaxRetries: 1, BaseDelay: time.Millisecond},
+		Cache:       NewLRUCache(10),
+		CacheTTL:    map[string]time.Duration{"": time.Minute},
+	}
+	for i := 0; i < 4; i++ {
+		NewAdGroupService(auth).Get(Selector{})
+	}
+
+	client.body = testFault("InternalApiError", "UNEXPECTED_INTERNAL_API_ERROR", "")
+	client.status = 500
+	if _, _, err := NewCampaignService(auth).Get(Selector{}); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	s := GetStat()
+	if s.Requests != 6 || s.Errors != 2 || s.Retries != 1 {
+		t.Errorf("got %d requests, %d errors and %d retries", s.Requests, s.Errors, s.Retries)
+	}
+	adGroups := s.ActionStat["AdGroupService.get"]
+	if adGroups == nil || adGroups.Requests != 4 || adGroups.Cached != 3 || adGroups.CacheHitRatio != 0.75 {
+		t.Errorf("got %+v", adGroups)
+	}
+	if adGroups.Latency.Count != 4 || len(adGroups.Latency.Counts) != len(LatencyBuckets) {
+		t.Errorf("got latency %+v", adGroups.Latency)
+	}
+	if s.ServiceStat["CampaignService"].Retries != 1 {
+		t.Errorf("got %+v", s.ServiceStat["CampaignService"])
+	}
+	if s.ErrorStat["InternalApiError.UNEXPECTED_INTERNAL_API_ERROR"] != 2 {
+		t.Errorf("got error stat %v", s.ErrorStat)
+	}
+
+	if v := expvar.Get("gads_v201809"); v == nil || !strings.Contains(v.String(), `"Requests":6`) {
+		t.Errorf("expected the stat to be published with expvar, got %v", v)
+	}
+
+	rec := httptest.NewRecorder()
+	StatHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := ioutil.ReadAll(rec.Body)
+	for _, expected := range []string{
+		"# TYPE gads_requests_total counter\n",
+		`gads_requests_total{service="AdGroupService",action="get"} 4`,
+		`gads_retries_total{service="CampaignService",action="get"} 1`,
+		`gads_error_reasons_total{reason="InternalApiError.UNEXPECTED_INTERNAL_API_ERROR"} 2`,
+		`gads_request_duration_seconds_bucket{service="CampaignService",action="get",le="+Inf"} 2`,
+		`gads_request_duration_seconds_count{service="AdGroupService",action="get"} 4`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("expected %s in\n%s", expected, body)
+		}
+	}
+}
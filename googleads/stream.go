@@ -0,0 +1,154 @@
+package v201809
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// entryFunc decodes one rval>entries element of a streamed response from dec.
+// It must consume the whole element, e.g. with dec.DecodeElement.
+type entryFunc func(dec *xml.Decoder, start *xml.StartElement) error
+
+// stream sends a get or query request and decodes the entries of the
+// response one at a time while it is read, instead of buffering the whole
+// response.  Failed requests are retried as long as no entry has been handed
+// to entry yet.  Streamed responses bypass the cache.
+func (a *Auth) stream(ctx context.Context, serviceUrl ServiceUrl, action string, body interface{}, entry entryFunc) (totalCount int64, err error) {
+	attempts := 0
+	err = a.withRetry(ctx, func() (bool, error) {
+		if attempts++; attempts > 1 {
+			stat.retry(serviceUrl.Name, action)
+		}
+		delivered := false
+		totalCount, err = a.streamFunc(ctx, serviceUrl, action, body, func(dec *xml.Decoder, start *xml.StartElement) error {
+			delivered = true
+			return entry(dec, start)
+		})
+		return !delivered, err
+	})
+	return totalCount, err
+}
+
+func (a *Auth) streamFunc(ctx context.Context, serviceUrl ServiceUrl, action string, body interface{}, entry entryFunc) (totalCount int64, err error) {
+	startTime := time.Now()
+
+	ex, err := a.soapExchange(ctx, serviceUrl, action, body)
+	if err != nil {
+		return 0, err
+	}
+	ex.stream = true
+
+	err = a.intercept(ctx, ex, a.soapStreamHandler)
+	if err == nil || (err == ex.Err && ex.undecoded()) {
+		var reader io.Reader
+		switch {
+		case ex.response != nil:
+			defer ex.response.Body.Close()
+			var body io.ReadCloser
+			if body, err = decodedBody(ex.response); err == nil {
+				defer body.Close()
+				reader = body
+			}
+		case ex.ResponseBody != nil && ex.StatusCode == http.StatusOK:
+			// answered by an interceptor
+			reader = bytes.NewReader(ex.ResponseBody)
+		case ex.undecoded():
+			decodeSoapResponse(ex)
+			err = ex.Err
+		default:
+			err = errors.New("no response to " + serviceUrl.Name + "." + action)
+		}
+		if reader != nil {
+			totalCount, err = decodeSoapStream(reader, &ex.SoapHeader, entry)
+			ex.Err = err
+		}
+	}
+	a.logExchange(ex, time.Since(startTime), err)
+
+	stat.count(serviceUrl.Name, action, false, false, time.Since(startTime), err)
+	if ex.StatusCode == 0 {
+		return totalCount, err
+	}
+
+	if ex.SoapHeader.RequestId != "" {
+		a.handleResponseHeader(ctx, ex.SoapHeader)
+	}
+	return totalCount, err
+}
+
+// soapStreamHandler sends a SOAP exchange and leaves a successful response
+// unread for decodeSoapStream.  Faults are small, they are read and decoded
+// as usual.
+func (a *Auth) soapStreamHandler(ctx context.Context, ex *Exchange) error {
+	if err := a.waitRateLimit(ctx); err != nil {
+		return err
+	}
+	if err := send(a.Client)(ctx, ex); err != nil {
+		return err
+	}
+	if ex.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	resp := ex.response
+	ex.response = nil
+	defer resp.Body.Close()
+	reader, err := decodedBody(resp)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if ex.ResponseBody, err = ioutil.ReadAll(reader); err != nil {
+		return err
+	}
+	decodeSoapResponse(ex)
+	return ex.Err
+}
+
+// decodeSoapStream reads a SOAP response envelope from r, storing its
+// ResponseHeader in header and handing every rval>entries element to entry.
+// It returns the rval>totalNumEntries of the response.
+func decodeSoapStream(r io.Reader, header *ResponseHeader, entry entryFunc) (totalCount int64, err error) {
+	dec := xml.NewDecoder(r)
+	// Envelope > Body > getResponse > rval > entries
+	depth := 0
+	for {
+		token, err := dec.Token()
+		if err == io.EOF {
+			if depth != 0 {
+				return totalCount, io.ErrUnexpectedEOF
+			}
+			return totalCount, nil
+		}
+		if err != nil {
+			return totalCount, err
+		}
+
+		switch start := token.(type) {
+		case xml.StartElement:
+			switch {
+			case depth == 2 && start.Name.Local == "ResponseHeader":
+				err = dec.DecodeElement(header, &start)
+			case depth == 4 && start.Name.Local == "totalNumEntries":
+				err = dec.DecodeElement(&totalCount, &start)
+			case depth == 4 && start.Name.Local == "entries":
+				err = entry(dec, &start)
+			case depth == 4:
+				err = dec.Skip()
+			default:
+				depth++
+			}
+			if err != nil {
+				return totalCount, err
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
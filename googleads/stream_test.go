@@ -0,0 +1,108 @@
+package v201809
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func soapGetResponse(entries string) string {
+	return `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Header><ResponseHeader xmlns="https://adwords.google.com/api/adwords/cm/v201809"><requestId>0005a1b2c3</requestId></ResponseHeader></soap:Header><soap:Body><getResponse xmlns="https://adwords.google.com/api/adwords/cm/v201809"><rval><totalNumEntries>10000</totalNumEntries><Page.Type>CampaignPage</Page.Type>` + entries + `</rval></getResponse></soap:Body></soap:Envelope>`
+}
+
+func TestStreamCampaigns(t *testing.T) {
+	client := &countingClient{status: 200, body: soapGetResponse(`<entries><id>1</id><name>one</name></entries><entries><id>2</id><name>two</name></entries><entries><id>3</id><name>three</name></entries>`)}
+	var header ResponseHeader
+	auth := &Auth{Client: client, ResponseHeaderHandler: func(h ResponseHeader) { header = h }}
+
+	var names []string
+	totalCount, err := NewCampaignService(auth).GetEach(Selector{}, func(c Campaign) error {
+		names = append(names, c.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if totalCount != 10000 {
+		t.Errorf("got totalCount %d", totalCount)
+	}
+	if expected := []string{"one", "two", "three"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("got %v, expected %v", names, expected)
+	}
+	if header.RequestId != "0005a1b2c3" {
+		t.Errorf("got response header %+v", header)
+	}
+}
+
+func TestStreamStopsOnCallbackError(t *testing.T) {
+	client := &countingClient{status: 200, body: soapGetResponse(`<entries><id>1</id></entries><entries><id>2</id></entries>`)}
+	auth := &Auth{Client: client, RetryPolicy: ExponentialBackoff{MaxRetries: 2, BaseDelay: time.Millisecond}}
+
+	stop := errors.New("stop")
+	var ids []int64
+	_, err := NewAdGroupService(auth).QueryEach("SELECT Id FROM AdGroup", func(ag AdGroup) error {
+		ids = append(ids, ag.Id)
+		return stop
+	})
+	if err != stop {
+		t.Errorf("got %v, expected the callback error", err)
+	}
+	if len(ids) != 1 || client.calls != 1 {
+		t.Errorf("got ids %v after %d calls", ids, client.calls)
+	}
+}
+
+func TestStreamTruncatedResponse(t *testing.T) {
+	body := soapGetResponse(`<entries><id>1</id></entries><entries><id>2</id></entries>`)
+	client := &countingClient{status: 200, body: body[:len(body)-30]}
+	auth := &Auth{Client: client, RetryPolicy: NoRetry}
+
+	var ids []int64
+	_, err := NewCampaignService(auth).GetEach(Selector{}, func(c Campaign) error {
+		ids = append(ids, c.Id)
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(ids) != 2 {
+		t.Errorf("got ids %v", ids)
+	}
+}
+
+func TestStreamRetriesFaults(t *testing.T) {
+	client := &countingClient{status: 500, body: testFault("InternalApiError", "UNEXPECTED_INTERNAL_API_ERROR", "")}
+	auth := &Auth{Client: client, RetryPolicy: ExponentialBackoff{MaxRetries: 1, BaseDelay: time.Millisecond}}
+
+	_, err := NewCampaignService(auth).GetEach(Selector{}, func(c Campaign) error { return nil })
+	if len(apiFaults(err)) != 1 {
+		t.Errorf("expected the decoded fault, got %#v", err)
+	}
+	if client.calls != 2 {
+		t.Errorf("got %d calls, expected 2", client.calls)
+	}
+}
+
+func TestStreamAdGroupCriteria(t *testing.T) {
+	client := &countingClient{status: 200, body: soapGetResponse(`<entries xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="BiddableAdGroupCriterion"><adGroupId>5</adGroupId><criterionUse>BIDDABLE</criterionUse><criterion xsi:type="Keyword"><id>7</id><type>KEYWORD</type><Criterion.Type>Keyword</Criterion.Type><text>shoes</text><matchType>EXACT</matchType></criterion><userStatus>ENABLED</userStatus></entries><entries xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="NegativeAdGroupCriterion"><adGroupId>5</adGroupId><criterionUse>NEGATIVE</criterionUse><criterion xsi:type="Keyword"><id>8</id><type>KEYWORD</type><Criterion.Type>Keyword</Criterion.Type><text>free</text><matchType>BROAD</matchType></criterion></entries>`)}
+	auth := &Auth{Client: client}
+
+	var criteria []interface{}
+	_, err := NewAdGroupCriterionService(auth).GetEach(Selector{}, func(agc interface{}) error {
+		criteria = append(criteria, agc)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(criteria) != 2 {
+		t.Fatalf("got %d criteria, expected 2", len(criteria))
+	}
+	if bagc, ok := criteria[0].(BiddableAdGroupCriterion); !ok || bagc.Criterion.(KeywordCriterion).Text != "shoes" {
+		t.Errorf("got %#v", criteria[0])
+	}
+	if _, ok := criteria[1].(NegativeAdGroupCriterion); !ok {
+		t.Errorf("got %#v", criteria[1])
+	}
+}
@@ -1,6 +1,7 @@
 package v201809
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 )
@@ -280,9 +281,16 @@ func searchParameterMarshalXML(sp SearchParameter, e *xml.Encoder) error {
 
 // Get Returns a page of ideas that match the query described by the specified TargetingIdeaSelector.
 // https://developers.google.com/adwords/api/docs/reference/v201809/TargetingIdeaService
-func (s *TargetingIdeaService) Get(selector TargetingIdeaSelector) (targetingIdeas []TargetingIdeas, totalCount int64, err error) {
+func (s *TargetingIdeaService) Get(selector TargetingIdeaSelector, opts ...CallOption) (targetingIdeas []TargetingIdeas, totalCount int64, err error) {
+	return s.GetWithContext(context.Background(), selector, opts...)
+}
+
+// GetWithContext is the same as Get with the addition of a context.
+func (s *TargetingIdeaService) GetWithContext(ctx context.Context, selector TargetingIdeaSelector, opts ...CallOption) (targetingIdeas []TargetingIdeas, totalCount int64, err error) {
+	ctx = withCallOptions(ctx, opts)
 
 	respBody, err := s.Auth.request(
+		ctx,
 		targetingIdeaServiceUrl,
 		"get",
 		struct {
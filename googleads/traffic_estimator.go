@@ -1,6 +1,9 @@
 package v201809
 
-import "encoding/xml"
+import (
+	"context"
+	"encoding/xml"
+)
 
 type TrafficEstimatorService struct {
 	Auth
@@ -110,9 +113,16 @@ type CampaignEstimate struct {
 //
 // 		https://developers.google.com/adwords/api/docs/reference/v201809/TrafficEstimatorService#get
 //
-func (s *TrafficEstimatorService) Get(selector TrafficEstimatorSelector) (res []CampaignEstimate, err error) {
+func (s *TrafficEstimatorService) Get(selector TrafficEstimatorSelector, opts ...CallOption) (res []CampaignEstimate, err error) {
+	return s.GetWithContext(context.Background(), selector, opts...)
+}
+
+// GetWithContext is the same as Get with the addition of a context.
+func (s *TrafficEstimatorService) GetWithContext(ctx context.Context, selector TrafficEstimatorSelector, opts ...CallOption) (res []CampaignEstimate, err error) {
+	ctx = withCallOptions(ctx, opts)
 
 	respBody, err := s.Auth.request(
+		ctx,
 		trafficEstimatorServiceUrl,
 		"get",
 		struct {